@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hieptle/gcp-guardrail/pkg/cmd/iam-server"
+)
+
+func main() {
+	cmd := iam_server.GetCommand()
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
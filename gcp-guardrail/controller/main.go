@@ -3,17 +3,23 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/hieptle/gcp-guardrail/pkg/rego"
-	
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	admissionv1 "k8s.io/api/admission/v1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -23,54 +29,117 @@ var (
 	runtimeScheme = runtime.NewScheme()
 	codecs        = serializer.NewCodecFactory(runtimeScheme)
 	deserializer  = codecs.UniversalDeserializer()
-	
+
 	// Policy paths
 	defaultPolicyDirs = []string{
 		"policies/kubernetes",
 	}
+
+	// auditLogger emits one structured JSON line per violation seen in
+	// audit mode, since audit mode's whole point is a record of what
+	// *would* have been denied rather than an enforced denial.
+	auditLogger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	admissionViolationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "admission_violations_total",
+			Help: "Total number of policy violations seen by the admission controller, regardless of mode.",
+		},
+		[]string{"policy", "namespace", "kind"},
+	)
+)
+
+// mode controls how the admission controller reacts to a policy
+// violation once it's found one.
+type mode string
+
+const (
+	// modeEnforce denies the request, as the controller always did
+	// before modes existed.
+	modeEnforce mode = "enforce"
+	// modeAudit always allows the request, recording violations as
+	// structured logs and Prometheus counters for later review.
+	modeAudit mode = "audit"
+	// modeDryRun always allows the request but annotates the
+	// AdmissionResponse with a warning per violation, so `kubectl apply`
+	// surfaces what would have been denied under enforce mode.
+	modeDryRun mode = "dry-run"
 )
 
+func parseMode(value string) mode {
+	switch mode(strings.ToLower(value)) {
+	case modeAudit:
+		return modeAudit
+	case modeDryRun:
+		return modeDryRun
+	default:
+		return modeEnforce
+	}
+}
+
 type admissionController struct {
-	evaluator *rego.Evaluator
+	evaluator          *rego.Evaluator
+	mode               mode
+	excludedNamespaces map[string]bool
 }
 
 // Initialize the admission controller
-func newAdmissionController(policyDirs []string) (*admissionController, error) {
+func newAdmissionController(policyDirs []string, m mode, excludedNamespaces []string) (*admissionController, error) {
 	evaluator, err := rego.NewEvaluator(context.Background(), policyDirs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create policy evaluator: %w", err)
 	}
-	
+
+	excluded := make(map[string]bool, len(excludedNamespaces))
+	for _, ns := range excludedNamespaces {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			excluded[ns] = true
+		}
+	}
+
 	return &admissionController{
-		evaluator: evaluator,
+		evaluator:          evaluator,
+		mode:               m,
+		excludedNamespaces: excluded,
 	}, nil
 }
 
-// Handle the admission review request
-func (ac *admissionController) handleAdmissionRequest(w http.ResponseWriter, r *http.Request) {
+// decodeAdmissionReview reads and parses the AdmissionReview request body
+// shared by the /validate and /mutate handlers, writing an HTTP error
+// and returning ok=false if it can't.
+func decodeAdmissionReview(w http.ResponseWriter, r *http.Request) (*admissionv1.AdmissionReview, bool) {
 	var body []byte
 	if r.Body != nil {
-		if data, err := r.Body.Read(body); err == nil {
+		if data, err := io.ReadAll(r.Body); err == nil {
 			body = data
 		}
 	}
-	
+
 	// Verify the content type
 	contentType := r.Header.Get("Content-Type")
 	if contentType != "application/json" {
 		log.Printf("contentType=%s, expected application/json", contentType)
 		http.Error(w, "Invalid Content-Type", http.StatusUnsupportedMediaType)
-		return
+		return nil, false
 	}
-	
-	// Parse the AdmissionReview request
-	reviewRequest := admissionv1.AdmissionReview{}
-	if _, _, err := deserializer.Decode(body, nil, &reviewRequest); err != nil {
+
+	reviewRequest := &admissionv1.AdmissionReview{}
+	if _, _, err := deserializer.Decode(body, nil, reviewRequest); err != nil {
 		log.Printf("Could not decode body: %v", err)
 		http.Error(w, "Invalid AdmissionReview request", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return reviewRequest, true
+}
+
+// Handle the admission review request
+func (ac *admissionController) handleAdmissionRequest(w http.ResponseWriter, r *http.Request) {
+	reviewRequest, ok := decodeAdmissionReview(w, r)
+	if !ok {
 		return
 	}
-	
+
 	// Initialize response
 	reviewResponse := admissionv1.AdmissionReview{
 		TypeMeta: reviewRequest.TypeMeta,
@@ -78,31 +147,148 @@ func (ac *admissionController) handleAdmissionRequest(w http.ResponseWriter, r *
 			UID: reviewRequest.Request.UID,
 		},
 	}
-	
-	// Evaluate the request against policies
-	allowed, reason, err := ac.evaluateRequest(reviewRequest.Request)
-	if err != nil {
-		log.Printf("Error evaluating request: %v", err)
-		reviewResponse.Response.Allowed = false
-		reviewResponse.Response.Result = &metav1.Status{
-			Message: fmt.Sprintf("Error evaluating request: %v", err),
-		}
+
+	if ac.excludedNamespaces[reviewRequest.Request.Namespace] {
+		reviewResponse.Response.Allowed = true
 	} else {
-		reviewResponse.Response.Allowed = allowed
-		if !allowed {
+		ac.admit(reviewRequest.Request, reviewResponse.Response)
+	}
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviewResponse)
+}
+
+// handleMutationRequest serves the MutatingAdmissionWebhook endpoint: it
+// evaluates kubernetes.mutation.<kind>'s "patch" rule and, if it
+// produced any operations, returns them as an RFC 6902 JSON Patch.
+func (ac *admissionController) handleMutationRequest(w http.ResponseWriter, r *http.Request) {
+	reviewRequest, ok := decodeAdmissionReview(w, r)
+	if !ok {
+		return
+	}
+
+	reviewResponse := admissionv1.AdmissionReview{
+		TypeMeta: reviewRequest.TypeMeta,
+		Response: &admissionv1.AdmissionResponse{
+			UID:     reviewRequest.Request.UID,
+			Allowed: true,
+		},
+	}
+
+	request := reviewRequest.Request
+	if !ac.excludedNamespaces[request.Namespace] {
+		if err := ac.mutate(request, reviewResponse.Response); err != nil {
+			log.Printf("Error evaluating mutation: %v", err)
+			reviewResponse.Response.Allowed = false
 			reviewResponse.Response.Result = &metav1.Status{
-				Message: reason,
+				Message: fmt.Sprintf("Error evaluating mutation: %v", err),
 			}
 		}
 	}
-	
-	// Send response
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(reviewResponse)
 }
 
+// mutate evaluates request against its kind's mutation policy and, if it
+// returned any patch operations, encodes them as a JSON Patch onto
+// response.
+func (ac *admissionController) mutate(request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse) error {
+	input := map[string]interface{}{
+		"kind":       request.Kind.Kind,
+		"name":       request.Name,
+		"namespace":  request.Namespace,
+		"operation":  request.Operation,
+		"object":     request.Object.Raw,
+		"oldObject":  request.OldObject.Raw,
+		"parameters": request.Options,
+	}
+
+	packagePath := fmt.Sprintf("kubernetes.mutation.%s", normalizeKind(request.Kind.Kind))
+	ops, err := ac.evaluator.EvaluateMutation(packagePath, input)
+	if err != nil {
+		return fmt.Errorf("mutation policy evaluation failed: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON patch: %w", err)
+	}
+
+	response.Patch = patch
+	patchType := admissionv1.PatchTypeJSONPatch
+	response.PatchType = &patchType
+	return nil
+}
+
+// admit evaluates request against policy and fills in response according
+// to ac.mode: enforce denies on violation, audit always allows while
+// recording violations, and dry-run always allows while surfacing
+// violations as response warnings.
+func (ac *admissionController) admit(request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse) {
+	violations, err := ac.evaluateRequest(request)
+	if err != nil {
+		log.Printf("Error evaluating request: %v", err)
+		response.Allowed = false
+		response.Result = &metav1.Status{
+			Message: fmt.Sprintf("Error evaluating request: %v", err),
+		}
+		return
+	}
+
+	if len(violations) == 0 {
+		response.Allowed = true
+		return
+	}
+
+	kind := normalizeKind(request.Kind.Kind)
+	for _, violation := range violations {
+		admissionViolationsTotal.WithLabelValues(violation.Policy, request.Namespace, kind).Inc()
+	}
+
+	switch ac.mode {
+	case modeAudit:
+		response.Allowed = true
+		for _, violation := range violations {
+			auditLogger.Warn("admission violation",
+				"mode", string(modeAudit),
+				"policy", violation.Policy,
+				"severity", violation.Severity,
+				"namespace", request.Namespace,
+				"kind", kind,
+				"name", request.Name,
+				"message", violation.Message,
+			)
+		}
+	case modeDryRun:
+		response.Allowed = true
+		for _, violation := range violations {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("[%s] %s", violation.Policy, violation.Message))
+		}
+	default: // modeEnforce
+		response.Allowed = false
+		response.Result = &metav1.Status{Message: joinMessages(violations)}
+	}
+}
+
+func joinMessages(violations []rego.Violation) string {
+	var reason string
+	for i, violation := range violations {
+		if i == 0 {
+			reason = violation.Message
+		} else {
+			reason = fmt.Sprintf("%s; %s", reason, violation.Message)
+		}
+	}
+	return reason
+}
+
 // Evaluate the admission request against the policies
-func (ac *admissionController) evaluateRequest(request *admissionv1.AdmissionRequest) (bool, string, error) {
+func (ac *admissionController) evaluateRequest(request *admissionv1.AdmissionRequest) ([]rego.Violation, error) {
 	// Convert the request to a format the OPA evaluator can process
 	input := map[string]interface{}{
 		"kind":       request.Kind.Kind,
@@ -113,30 +299,17 @@ func (ac *admissionController) evaluateRequest(request *admissionv1.AdmissionReq
 		"oldObject":  request.OldObject.Raw,
 		"parameters": request.Options,
 	}
-	
+
 	// Determine the package path based on the resource kind
 	packagePath := fmt.Sprintf("kubernetes.admission.%s", normalizeKind(request.Kind.Kind))
-	
+
 	// Evaluate the policies
 	result, err := ac.evaluator.Evaluate(packagePath, input)
 	if err != nil {
-		return false, "", fmt.Errorf("policy evaluation failed: %w", err)
-	}
-	
-	// If there are any violations, deny the request
-	if len(result.Violations) > 0 {
-		var reason string
-		for i, violation := range result.Violations {
-			if i == 0 {
-				reason = violation.Message
-			} else {
-				reason = fmt.Sprintf("%s; %s", reason, violation.Message)
-			}
-		}
-		return false, reason, nil
+		return nil, fmt.Errorf("policy evaluation failed: %w", err)
 	}
-	
-	return true, "", nil
+
+	return result.Violations, nil
 }
 
 // Normalize the Kubernetes kind name
@@ -152,37 +325,57 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
+	modeFlag := flag.String("mode", "enforce", "Admission controller mode: enforce (deny on violation), audit (always allow, log/count violations), or dry-run (always allow, annotate warnings)")
+	metricsAddr := flag.String("metrics-addr", ":9090", "Address the /metrics endpoint listens on")
+	excludeNamespaces := flag.String("exclude-namespaces", "kube-system,kube-public,kube-node-lease", "Comma-separated namespaces to always allow without evaluation")
+	flag.Parse()
+
 	// Get policy directories from environment variable or use default
 	policyDirsEnv := os.Getenv("POLICY_DIRS")
 	policyDirs := defaultPolicyDirs
 	if policyDirsEnv != "" {
 		policyDirs = filepath.SplitList(policyDirsEnv)
 	}
-	
+
+	m := parseMode(*modeFlag)
+
 	// Create the admission controller
-	ac, err := newAdmissionController(policyDirs)
+	ac, err := newAdmissionController(policyDirs, m, strings.Split(*excludeNamespaces, ","))
 	if err != nil {
 		log.Fatalf("Failed to create admission controller: %v", err)
 	}
-	
+	log.Printf("Admission controller mode: %s", m)
+
 	// Create HTTP server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", healthCheckHandler)
 	mux.HandleFunc("/validate", ac.handleAdmissionRequest)
-	
+	mux.HandleFunc("/mutate", ac.handleMutationRequest)
+
 	// Get port from environment variable or use default
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8443"
 	}
-	
+
 	// Start the HTTP server
 	log.Printf("Starting server on port %s", port)
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
 		Handler: mux,
 	}
-	
+
+	// Serve /metrics on a separate listener so it isn't exposed through
+	// whatever ingress/TLS termination fronts the webhook.
+	go func() {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		log.Printf("Starting metrics server on %s", *metricsAddr)
+		if err := http.ListenAndServe(*metricsAddr, metricsMux); err != nil {
+			log.Printf("metrics server failed: %v", err)
+		}
+	}()
+
 	// Start the server with TLS if certificates are provided
 	certFile := os.Getenv("TLS_CERT_FILE")
 	keyFile := os.Getenv("TLS_KEY_FILE")
@@ -191,4 +384,4 @@ func main() {
 	} else {
 		log.Fatal(server.ListenAndServe())
 	}
-} 
\ No newline at end of file
+}
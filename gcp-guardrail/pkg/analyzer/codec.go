@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodecName is the grpc content-subtype analyzer plugin calls are
+// negotiated under (see grpc.CallContentSubtype in service.go), so these
+// RPCs carry JSON-encoded messages instead of real protobuf wire
+// encoding.
+const jsonCodecName = "json"
+
+// jsonCodec implements encoding.Codec, letting the AnalyzerService use
+// grpc's transport/handshake machinery without requiring the protoc-
+// generated proto.Message implementations the default codec expects.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return jsonCodecName
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+)
+
+// BinaryPrefix is the naming convention analyzer plugin binaries must
+// follow to be discovered on $PATH, e.g. "gcpgolang-analyzer-checkov".
+const BinaryPrefix = "gcpgolang-analyzer-"
+
+// Handshake is the shared handshake both plugin host and plugin binary
+// must agree on before hashicorp/go-plugin will talk to each other.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "GCPGOLANG_ANALYZER_PLUGIN",
+	MagicCookieValue: "3f8e6d2a-analyzer-plugin",
+}
+
+// pluginMap is the set of plugin kinds this host negotiates; there is
+// only one, named "analyzer".
+var pluginMap = map[string]plugin.Plugin{
+	"analyzer": &grpcPlugin{},
+}
+
+// grpcPlugin adapts AnalyzerServer to hashicorp/go-plugin's GRPCPlugin
+// interface, wiring the jsonCodec content-subtype on both ends instead
+// of the default protobuf codec (see codec.go).
+type grpcPlugin struct {
+	plugin.Plugin
+	Impl AnalyzerServer
+}
+
+func (p *grpcPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	RegisterAnalyzerServer(s, p.Impl)
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return NewAnalyzerClient(conn), nil
+}
+
+// Discover walks every directory in $PATH looking for executables named
+// BinaryPrefix+<name>, returning their absolute paths. The first match
+// for a given name wins, following $PATH's own precedence; unreadable
+// directories are skipped rather than failing the whole scan.
+func Discover() ([]string, error) {
+	seen := make(map[string]bool)
+	var found []string
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), BinaryPrefix) {
+				continue
+			}
+			if seen[entry.Name()] {
+				continue
+			}
+			seen[entry.Name()] = true
+			found = append(found, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return found, nil
+}
+
+// Client wraps a launched analyzer plugin process and its AnalyzerServer
+// client stub.
+type Client struct {
+	Name   string
+	server AnalyzerServer
+	client *plugin.Client
+}
+
+// Launch starts the analyzer plugin binary at binaryPath, performs the
+// go-plugin stdio handshake, and returns a ready-to-use Client.
+func Launch(binaryPath string) (*Client, error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginMap,
+		Cmd:             exec.Command(binaryPath),
+		AllowedProtocols: []plugin.Protocol{
+			plugin.ProtocolGRPC,
+		},
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("connect to analyzer plugin %s: %w", binaryPath, err)
+	}
+
+	raw, err := rpcClient.Dispense("analyzer")
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("dispense analyzer plugin %s: %w", binaryPath, err)
+	}
+
+	server, ok := raw.(AnalyzerServer)
+	if !ok {
+		client.Kill()
+		return nil, fmt.Errorf("analyzer plugin %s did not return an AnalyzerServer", binaryPath)
+	}
+
+	return &Client{
+		Name:   strings.TrimPrefix(filepath.Base(binaryPath), BinaryPrefix),
+		server: server,
+		client: client,
+	}, nil
+}
+
+// AnalyzeStack calls the plugin's AnalyzeStack RPC with resources.
+func (c *Client) AnalyzeStack(ctx context.Context, resources []ResourceProperties) ([]Diagnostic, error) {
+	resp, err := c.server.AnalyzeStack(ctx, &AnalyzeStackRequest{Resources: resources})
+	if err != nil {
+		return nil, fmt.Errorf("analyzer plugin %s: %w", c.Name, err)
+	}
+	return resp.Diagnostics, nil
+}
+
+// Close terminates the plugin process.
+func (c *Client) Close() {
+	c.client.Kill()
+}
+
+// Serve runs impl as an analyzer plugin binary, blocking until the host
+// process disconnects. Plugin binary authors call this from their main().
+func Serve(impl AnalyzerServer) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: map[string]plugin.Plugin{
+			"analyzer": &grpcPlugin{Impl: impl},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// AnalyzerServer is the interface an analyzer plugin implements,
+// equivalent to what protoc-gen-go-grpc would generate as the server
+// interface for analyzer.proto's AnalyzerService.
+type AnalyzerServer interface {
+	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	AnalyzeStack(context.Context, *AnalyzeStackRequest) (*AnalyzeResponse, error)
+	GetAnalyzerInfo(context.Context, *Empty) (*AnalyzerInfo, error)
+	Remediate(context.Context, *RemediateRequest) (*RemediateResponse, error)
+}
+
+const serviceName = "gcpgolang.analyzer.v1.AnalyzerService"
+
+// RegisterAnalyzerServer registers impl to handle the AnalyzerService's
+// RPCs on s.
+func RegisterAnalyzerServer(s *grpc.Server, impl AnalyzerServer) {
+	s.RegisterService(&analyzerServiceDesc, impl)
+}
+
+var analyzerServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*AnalyzerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Analyze", Handler: analyzeHandler},
+		{MethodName: "AnalyzeStack", Handler: analyzeStackHandler},
+		{MethodName: "GetAnalyzerInfo", Handler: getAnalyzerInfoHandler},
+		{MethodName: "Remediate", Handler: remediateHandler},
+	},
+	Metadata: "analyzer.proto",
+}
+
+func analyzeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/Analyze"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Analyze(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func analyzeStackHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeStackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).AnalyzeStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/AnalyzeStack"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).AnalyzeStack(ctx, req.(*AnalyzeStackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAnalyzerInfoHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).GetAnalyzerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetAnalyzerInfo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).GetAnalyzerInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func remediateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemediateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/Remediate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Remediate(ctx, req.(*RemediateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// analyzerClient is the generated-style client stub for AnalyzerServer.
+type analyzerClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewAnalyzerClient wraps conn in an AnalyzerServer-shaped client stub,
+// invoking every RPC over the jsonCodec content-subtype.
+func NewAnalyzerClient(conn *grpc.ClientConn) AnalyzerServer {
+	return &analyzerClient{cc: conn}
+}
+
+func (c *analyzerClient) Analyze(ctx context.Context, in *AnalyzeRequest) (*AnalyzeResponse, error) {
+	out := new(AnalyzeResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/Analyze", in, out, grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest) (*AnalyzeResponse, error) {
+	out := new(AnalyzeResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/AnalyzeStack", in, out, grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) GetAnalyzerInfo(ctx context.Context, in *Empty) (*AnalyzerInfo, error) {
+	out := new(AnalyzerInfo)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetAnalyzerInfo", in, out, grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) Remediate(ctx context.Context, in *RemediateRequest) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/Remediate", in, out, grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
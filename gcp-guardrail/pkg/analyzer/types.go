@@ -0,0 +1,88 @@
+// Package analyzer implements the gRPC-based analyzer plugin protocol
+// described in analyzer.proto: a way for tf-validator, misconfig-scanner,
+// and iam-analyzer to discover external policy-pack binaries on $PATH
+// (named gcpgolang-analyzer-<name>), hand-shake with them over stdio the
+// way hashicorp/go-plugin does, and merge their Diagnostics with
+// in-process Rego results.
+//
+// This environment has no protoc/protoc-gen-go-grpc available to
+// generate the usual *.pb.go from analyzer.proto, so the message types
+// below are hand-maintained as plain Go structs and (de)serialized with
+// jsonCodec (see codec.go) registered under grpc's content-subtype
+// negotiation, rather than real protobuf binary encoding. A real build
+// should regenerate these from analyzer.proto and switch the client/
+// server in service.go back to the default protobuf codec.
+package analyzer
+
+// EnforcementLevel mirrors Pulumi's policy enforcement levels.
+type EnforcementLevel string
+
+const (
+	Advisory  EnforcementLevel = "advisory"
+	Mandatory EnforcementLevel = "mandatory"
+	Disabled  EnforcementLevel = "disabled"
+	Remediate EnforcementLevel = "remediate"
+)
+
+// ResourceProperties is the resource under analysis: a URN identifying
+// it, its type, and its rendered property bag. Properties is a plain
+// map rather than a typed struct since resource shapes vary by type and
+// this wire format is shared across GCP IAM bindings, Terraform planned
+// resources, and misconfig-scanner findings alike.
+type ResourceProperties struct {
+	URN        string                 `json:"urn"`
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Diagnostic is a single policy finding returned by an analyzer plugin.
+type Diagnostic struct {
+	PolicyName       string           `json:"policy_name"`
+	Description      string           `json:"description,omitempty"`
+	Message          string           `json:"message"`
+	URN              string           `json:"urn"`
+	Severity         string           `json:"severity"`
+	EnforcementLevel EnforcementLevel `json:"enforcement_level"`
+	Remediation      string           `json:"remediation,omitempty"`
+}
+
+// Empty is the (no-argument) request for GetAnalyzerInfo.
+type Empty struct{}
+
+// AnalyzeRequest asks the plugin to evaluate a single resource.
+type AnalyzeRequest struct {
+	Resource ResourceProperties `json:"resource"`
+}
+
+// AnalyzeStackRequest asks the plugin to evaluate every resource in a
+// plan/project together, for policies that reason about cross-resource
+// relationships (e.g. "every VM must sit behind a load balancer").
+type AnalyzeStackRequest struct {
+	Resources []ResourceProperties `json:"resources"`
+}
+
+// AnalyzeResponse carries the diagnostics an analyzer produced for an
+// AnalyzeRequest or AnalyzeStackRequest.
+type AnalyzeResponse struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// AnalyzerInfo describes a plugin, returned by GetAnalyzerInfo.
+type AnalyzerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// RemediateRequest asks the plugin to auto-fix a resource that one of
+// its own policies flagged.
+type RemediateRequest struct {
+	Resource   ResourceProperties `json:"resource"`
+	PolicyName string             `json:"policy_name"`
+}
+
+// RemediateResponse carries the remediated property set, if the plugin
+// was able to fix the violation.
+type RemediateResponse struct {
+	Remediated bool                   `json:"remediated"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
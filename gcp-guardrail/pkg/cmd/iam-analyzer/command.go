@@ -1,12 +1,20 @@
 package iam_analyzer
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	analyzerplugin "github.com/hieptle/gcp-guardrail/pkg/analyzer"
 	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/archive"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/baseline"
+	"github.com/hieptle/gcp-guardrail/pkg/log"
+	sharedreport "github.com/hieptle/gcp-guardrail/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,10 +24,48 @@ var (
 	projectID    string
 	reportFormat string
 	outputPath   string
-	verbose      bool
+	logLevel     string
 	riskLevel    string
+	checks       []string
+	skipChecks   []string
+	failOn       string
+
+	baselineDB        string
+	baselineGCSBucket string
+	baselineGCSPrefix string
+	saveBaseline      bool
+
+	archiveDir       string
+	archiveGCSBucket string
+	archiveGCSPrefix string
+	saveArchive      bool
+	diffAgainst      string
+
+	stream bool
+
+	enableAnalyzerPlugins bool
+
+	recommendRoles bool
+	usageWindow    time.Duration
 )
 
+// severityRank maps a severity name to an integer so --risk-level and
+// --fail-on can compare against it.
+func severityRank(severity string) int {
+	switch severity {
+	case "CRITICAL":
+		return 5
+	case "HIGH":
+		return 4
+	case "MEDIUM":
+		return 3
+	case "LOW":
+		return 2
+	default:
+		return 1
+	}
+}
+
 // GetCommand returns the iam-analyzer command
 func GetCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -29,97 +75,57 @@ func GetCommand() *cobra.Command {
 policy violations, and security risks.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create context
-			// ctx := context.Background()
+			ctx := context.Background()
+			logger := log.New(os.Stderr, logLevel)
+
+			logger.Info("analyzing IAM policies", "project", projectID, "risk_level", riskLevel)
 
-			if verbose {
-				fmt.Printf("Analyzing IAM policies for project %s\n", projectID)
-				fmt.Printf("Risk level filter: %s\n", riskLevel)
-				fmt.Printf("Analysis started at: %s\n", time.Now().Format(time.RFC3339))
-			}
-			
 			// Convert risk level to integer
 			riskInt, err := strconv.Atoi(riskLevel)
 			if err != nil {
-				fmt.Printf("Warning: Invalid risk level '%s', using default (3)\n", riskLevel)
+				logger.Warn("invalid risk level, using default", "risk_level", riskLevel, "default", 3)
 				riskInt = 3 // Default if not a number
 			}
-			
-			// In a real implementation, we would initialize the analyzer and use it
-			// ctx := context.Background()
-			// analyzer, err := iam.NewAnalyzer(ctx, projectID)
-			// if err != nil {
-			//    fmt.Printf("Error: Failed to create IAM analyzer: %v\n", err)
-			//    os.Exit(1)
-			// }
-			
-			// For now, we'll create a sample analysis with test data
-			// In the future, this would call analyzer.AnalyzeProject()
-			analysis := &iam.Analysis{
-				ProjectID: projectID,
-				Timestamp: time.Now(),
-				Issues: []iam.Issue{
-					{
-						Severity:    "CRITICAL",
-						Description: "User account has Owner role at organization level",
-						Principal:   "user:admin@example.com",
-						Role:        "roles/owner",
-						Mitigation:  "Remove Owner role and grant more specific roles",
-					},
-					{
-						Severity:    "HIGH",
-						Description: "Service account has broad permissions",
-						Principal:   "serviceAccount:sa@project.iam.gserviceaccount.com",
-						Role:        "roles/editor",
-						Mitigation:  "Grant only required permissions to service account",
-					},
-					{
-						Severity:    "MEDIUM",
-						Description: "Group has compute admin permissions",
-						Principal:   "group:engineers@example.com",
-						Role:        "roles/compute.admin",
-						Mitigation:  "Limit compute admin access to specific principals",
-					},
-					{
-						Severity:    "LOW",
-						Description: "User has viewer permissions across multiple projects",
-						Principal:   "user:viewer@example.com",
-						Role:        "roles/viewer",
-						Mitigation:  "Review necessity for cross-project access",
-					},
-				},
-				RoleAssignments: []iam.RoleAssignment{
-					{
-						Principal: "user:admin@example.com",
-						Role:      "roles/owner",
-						Scope:     "organization/123456789",
-					},
-					{
-						Principal: "serviceAccount:sa@project.iam.gserviceaccount.com",
-						Role:      "roles/editor",
-						Scope:     "project/" + projectID,
-					},
-				},
+
+			// Create the IAM analyzer and run the full project analysis
+			analyzer, err := iam.NewAnalyzer(ctx, projectID)
+			if err != nil {
+				logger.Error("failed to create IAM analyzer", "error", err)
+				os.Exit(1)
+			}
+			analyzer.SetChecks(checks, skipChecks)
+			analyzer.SetUsageAnalysis(usageWindow, recommendRoles)
+
+			// --stream bypasses risk-level filtering, baselining, and
+			// archiving: it streams role assignments, issues, and (per
+			// SetUsageAnalysis above) unused permissions straight to the
+			// output writer as AnalyzeProjectStreaming discovers them, so
+			// none of those post-processing steps (which all need the
+			// complete Analysis) apply to it.
+			if stream {
+				runStreamingAnalysis(analyzer, logger)
+				return
+			}
+
+			analysis, err := analyzer.AnalyzeProject()
+			if err != nil {
+				logger.Error("failed to analyze project", "error", err)
+				os.Exit(1)
+			}
+
+			if enableAnalyzerPlugins {
+				pluginIssues, err := runAnalyzerPlugins(ctx, analysis.RoleAssignments)
+				if err != nil {
+					logger.Warn("failed to run analyzer plugins", "error", err)
+				} else {
+					analysis.Issues = append(analysis.Issues, pluginIssues...)
+				}
 			}
-			
+
 			// Filter issues based on risk level
 			var filteredIssues []iam.Issue
 			for _, issue := range analysis.Issues {
-				// Convert severity to risk level (simplified mapping)
-				var issueRisk int
-				switch issue.Severity {
-				case "CRITICAL":
-					issueRisk = 5
-				case "HIGH":
-					issueRisk = 4
-				case "MEDIUM":
-					issueRisk = 3
-				case "LOW":
-					issueRisk = 2
-				default:
-					issueRisk = 1
-				}
-				
-				if issueRisk >= riskInt {
+				if severityRank(issue.Severity) >= riskInt {
 					filteredIssues = append(filteredIssues, issue)
 				}
 			}
@@ -127,28 +133,84 @@ policy violations, and security risks.`,
 
 			// Create report from analysis
 			report := iam.NewReport(analysis)
-			
-			// Determine report format
-			var format iam.ReportFormat
-			switch reportFormat {
-			case "json":
-				format = iam.JSONFormat
-			case "csv":
-				format = iam.CSVFormat
-			default:
-				format = iam.TextFormat
+
+			if saveBaseline {
+				store, closeStore, err := openBaselineStore(ctx)
+				if err != nil {
+					logger.Warn("failed to open baseline store, skipping snapshot", "error", err)
+				} else {
+					if err := baseline.SaveIAMReport(store, report.GeneratedAt, report); err != nil {
+						logger.Warn("failed to save baseline snapshot", "error", err)
+					}
+					closeStore()
+				}
 			}
-			
-			// Output the report
-			if err := iam.WriteReportToFile(outputPath, report, format); err != nil {
-				fmt.Printf("Error: Failed to write report: %v\n", err)
-				os.Exit(1)
+
+			if saveArchive || diffAgainst != "" {
+				archiveStore, err := openArchiveStore(ctx)
+				if err != nil {
+					logger.Warn("failed to open archive store", "error", err)
+				} else {
+					if diffAgainst != "" {
+						archivedReport, archivedAt, err := archive.LoadIAMReport(archiveStore, projectID, diffAgainst)
+						if err != nil {
+							logger.Error("failed to load archived report for --diff-against", "ref", diffAgainst, "error", err)
+							os.Exit(1)
+						}
+						logger.Info("diffing against archived report", "project", projectID, "archived_at", archivedAt)
+						report.Baseline = archivedReport
+					}
+					if saveArchive {
+						if err := archive.SaveIAMReport(archiveStore, report.GeneratedAt, report); err != nil {
+							logger.Warn("failed to archive report", "error", err)
+						}
+					}
+				}
 			}
-			
-			if verbose {
-				fmt.Printf("Analysis completed. Found %d policy violations.\n", len(analysis.Issues))
+
+			// Output the report. "github" has no iam.ReportFormat of its
+			// own (it's rendered from the shared Finding conversion, not
+			// iam's own writers), so it's handled before parseReportFormat.
+			if reportFormat == "github" {
+				w := os.Stdout
 				if outputPath != "" {
-					fmt.Printf("Report written to %s\n", outputPath)
+					f, err := os.Create(outputPath)
+					if err != nil {
+						logger.Error("failed to create output file", "error", err)
+						os.Exit(1)
+					}
+					defer f.Close()
+					w = f
+				}
+				if err := sharedreport.Write(w, "gcpgolang-iam-analyzer", iam.ToFindings(report), sharedreport.GitHubFormat); err != nil {
+					logger.Error("failed to write report", "error", err)
+					os.Exit(1)
+				}
+			} else {
+				format := parseReportFormat(reportFormat)
+				if err := iam.WriteReportToFile(outputPath, report, format); err != nil {
+					logger.Error("failed to write report", "error", err)
+					os.Exit(1)
+				}
+			}
+
+			logger.Info("analysis completed", "issues_found", len(analysis.Issues), "output", outputPath)
+
+			// Fail the command with a non-zero exit code if any issue meets
+			// or exceeds the --fail-on severity. With --diff-against set,
+			// only newly introduced issues count, so accepted pre-existing
+			// risk doesn't keep failing every run.
+			issuesToCheck := analysis.Issues
+			if report.Baseline != nil {
+				issuesToCheck = report.DiffSinceBaseline().NewIssues
+			}
+			if failOn != "" && failOn != "none" {
+				threshold := severityRank(strings.ToUpper(failOn))
+				for _, issue := range issuesToCheck {
+					if severityRank(issue.Severity) >= threshold {
+						logger.Error("failing: found issues at or above fail-on severity", "fail_on", strings.ToUpper(failOn))
+						os.Exit(1)
+					}
 				}
 			}
 		},
@@ -156,18 +218,189 @@ policy violations, and security risks.`,
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.iam-analyzer.yaml)")
 	rootCmd.PersistentFlags().StringVar(&projectID, "project", "", "GCP project ID")
-	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv)")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv, sarif, junit, github; --stream also supports ndjson)")
 	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output file path (default is stdout)")
-	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&riskLevel, "risk-level", "3", "Minimum risk level to report (1-5)")
+	rootCmd.PersistentFlags().StringSliceVar(&checks, "checks", nil, "Only run these checks (default: all registered checks)")
+	rootCmd.PersistentFlags().StringSliceVar(&skipChecks, "skip-checks", nil, "Skip these checks")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "none", "Exit non-zero if any issue at or above this severity is found (critical, high, medium, low, none)")
+	rootCmd.PersistentFlags().StringVar(&baselineDB, "baseline-db", "", "SQLite baseline database path (default: $HOME/.gcp-guardrail/baselines/iam.db)")
+	rootCmd.PersistentFlags().StringVar(&baselineGCSBucket, "baseline-gcs-bucket", "", "Store baseline snapshots in this GCS bucket instead of a local SQLite database")
+	rootCmd.PersistentFlags().StringVar(&baselineGCSPrefix, "baseline-gcs-prefix", "", "Object prefix to use within --baseline-gcs-bucket")
+	rootCmd.PersistentFlags().BoolVar(&saveBaseline, "save-baseline", true, "Record this run's report as a baseline snapshot for future `diff` comparisons")
+	rootCmd.PersistentFlags().StringVar(&archiveDir, "archive-dir", "", "Directory to archive reports in (default: $HOME/.gcp-guardrail/archives)")
+	rootCmd.PersistentFlags().StringVar(&archiveGCSBucket, "archive-gcs-bucket", "", "Archive reports in this GCS bucket instead of a local directory")
+	rootCmd.PersistentFlags().StringVar(&archiveGCSPrefix, "archive-gcs-prefix", "", "Object prefix to use within --archive-gcs-bucket")
+	rootCmd.PersistentFlags().BoolVar(&saveArchive, "archive", false, "Archive this run's report so it can be referenced later by --diff-against or iam-server")
+	rootCmd.PersistentFlags().StringVar(&diffAgainst, "diff-against", "", "Diff this run against an archived report (\"latest\" or an RFC3339 timestamp)")
+	rootCmd.PersistentFlags().BoolVar(&stream, "stream", false, "Stream role assignments and issues straight to the output as they are discovered, instead of buffering the full report in memory")
+	rootCmd.PersistentFlags().BoolVar(&enableAnalyzerPlugins, "enable-analyzer-plugins", false, "Discover and run analyzer plugins (binaries named gcpgolang-analyzer-* on $PATH) and merge their diagnostics in as additional issues")
+	rootCmd.PersistentFlags().BoolVar(&recommendRoles, "recommend-roles", false, "Suggest least-privilege predefined roles for each principal's UnusedPermissions, based on its actually-used permissions")
+	rootCmd.PersistentFlags().DurationVar(&usageWindow, "usage-window", 90*24*time.Hour, "How far back to query Cloud Audit Logs when computing unused permissions")
 
 	rootCmd.MarkPersistentFlagRequired("project")
 
 	viper.BindPFlag("project", rootCmd.PersistentFlags().Lookup("project"))
 	viper.BindPFlag("report-format", rootCmd.PersistentFlags().Lookup("report-format"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 	viper.BindPFlag("risk-level", rootCmd.PersistentFlags().Lookup("risk-level"))
+	viper.BindPFlag("checks", rootCmd.PersistentFlags().Lookup("checks"))
+	viper.BindPFlag("skip-checks", rootCmd.PersistentFlags().Lookup("skip-checks"))
+	viper.BindPFlag("fail-on", rootCmd.PersistentFlags().Lookup("fail-on"))
+	viper.BindPFlag("baseline-db", rootCmd.PersistentFlags().Lookup("baseline-db"))
+	viper.BindPFlag("baseline-gcs-bucket", rootCmd.PersistentFlags().Lookup("baseline-gcs-bucket"))
+	viper.BindPFlag("baseline-gcs-prefix", rootCmd.PersistentFlags().Lookup("baseline-gcs-prefix"))
+	viper.BindPFlag("save-baseline", rootCmd.PersistentFlags().Lookup("save-baseline"))
+	viper.BindPFlag("archive-dir", rootCmd.PersistentFlags().Lookup("archive-dir"))
+	viper.BindPFlag("archive-gcs-bucket", rootCmd.PersistentFlags().Lookup("archive-gcs-bucket"))
+	viper.BindPFlag("archive-gcs-prefix", rootCmd.PersistentFlags().Lookup("archive-gcs-prefix"))
+	viper.BindPFlag("archive", rootCmd.PersistentFlags().Lookup("archive"))
+	viper.BindPFlag("diff-against", rootCmd.PersistentFlags().Lookup("diff-against"))
+	viper.BindPFlag("stream", rootCmd.PersistentFlags().Lookup("stream"))
+	viper.BindPFlag("enable-analyzer-plugins", rootCmd.PersistentFlags().Lookup("enable-analyzer-plugins"))
+	viper.BindPFlag("recommend-roles", rootCmd.PersistentFlags().Lookup("recommend-roles"))
+	viper.BindPFlag("usage-window", rootCmd.PersistentFlags().Lookup("usage-window"))
+
+	rootCmd.AddCommand(newDiffCommand())
 
 	return rootCmd
-} 
\ No newline at end of file
+}
+
+// openBaselineStore opens the baseline.Store selected by --baseline-gcs-bucket
+// (if set) or --baseline-db (falling back to its default path), shared by
+// the root Run and the diff subcommand. The returned closer must be
+// deferred by the caller; it's a no-op for the GCS-backed store.
+func openBaselineStore(ctx context.Context) (baseline.Store, func() error, error) {
+	if baselineGCSBucket != "" {
+		store, err := baseline.NewGCSStore(ctx, baselineGCSBucket, baselineGCSPrefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, func() error { return nil }, nil
+	}
+
+	path := baselineDB
+	if path == "" {
+		var err error
+		path, err = baseline.DefaultPath("iam")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	store, err := baseline.NewSQLiteStore(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, store.Close, nil
+}
+
+// openArchiveStore opens the archive.Store selected by --archive-gcs-bucket
+// (if set) or --archive-dir (falling back to its default directory).
+func openArchiveStore(ctx context.Context) (archive.Store, error) {
+	if archiveGCSBucket != "" {
+		return archive.NewGCSStore(ctx, archiveGCSBucket, archiveGCSPrefix)
+	}
+	return archive.NewFileStore(archiveDir)
+}
+
+// parseReportFormat maps the --report-format flag value to an
+// iam.ReportFormat, defaulting to TextFormat for an unrecognized value.
+func parseReportFormat(reportFormat string) iam.ReportFormat {
+	switch reportFormat {
+	case "json":
+		return iam.JSONFormat
+	case "csv":
+		return iam.CSVFormat
+	case "sarif":
+		return iam.SARIFFormat
+	case "junit":
+		return iam.JUnitFormat
+	case "ndjson":
+		return iam.NDJSONFormat
+	default:
+		return iam.TextFormat
+	}
+}
+
+// runAnalyzerPlugins discovers analyzer plugin binaries on $PATH, runs
+// AnalyzeStack against the project's role assignments, and converts each
+// returned diagnostic into an iam.Issue so plugin and built-in checks
+// share one report.
+func runAnalyzerPlugins(ctx context.Context, assignments []iam.RoleAssignment) ([]iam.Issue, error) {
+	binaries, err := analyzerplugin.Discover()
+	if err != nil {
+		return nil, fmt.Errorf("discover analyzer plugins: %w", err)
+	}
+	if len(binaries) == 0 {
+		return nil, nil
+	}
+
+	resources := make([]analyzerplugin.ResourceProperties, 0, len(assignments))
+	for _, a := range assignments {
+		resources = append(resources, analyzerplugin.ResourceProperties{
+			URN:  a.Principal,
+			Type: a.Role,
+		})
+	}
+
+	var issues []iam.Issue
+	for _, binaryPath := range binaries {
+		client, err := analyzerplugin.Launch(binaryPath)
+		if err != nil {
+			fmt.Printf("Failed to launch analyzer plugin %s: %v\n", binaryPath, err)
+			continue
+		}
+
+		diagnostics, err := client.AnalyzeStack(ctx, resources)
+		client.Close()
+		if err != nil {
+			fmt.Printf("Analyzer plugin %s failed: %v\n", client.Name, err)
+			continue
+		}
+
+		for _, d := range diagnostics {
+			issues = append(issues, iam.Issue{
+				Severity:    strings.ToUpper(d.Severity),
+				Description: d.Message,
+				Principal:   d.URN,
+				Mitigation:  d.Remediation,
+				CheckName:   d.PolicyName,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// runStreamingAnalysis implements the --stream path: it opens the
+// configured output (stdout, or --output if set) and a
+// StreamingReportWriter for --report-format, then has the analyzer push
+// role assignments and issues directly into it.
+func runStreamingAnalysis(analyzer *iam.Analyzer, logger *slog.Logger) {
+	out := os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			logger.Error("failed to create output file", "error", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w, err := iam.NewStreamingReportWriter(out, parseReportFormat(reportFormat))
+	if err != nil {
+		logger.Error("failed to create streaming report writer", "error", err)
+		os.Exit(1)
+	}
+
+	if err := analyzer.AnalyzeProjectStreaming(w); err != nil {
+		logger.Error("failed to analyze project", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("streaming analysis completed", "output", outputPath)
+}
\ No newline at end of file
@@ -0,0 +1,123 @@
+package iam_analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/baseline"
+	"github.com/hieptle/gcp-guardrail/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+var diffSince string
+
+// newDiffCommand returns the `iam-analyzer diff` subcommand, which
+// re-analyzes the project, loads the newest baseline snapshot taken
+// before --since, and reports what's changed.
+func newDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the current IAM state against a prior baseline snapshot",
+		Long: `Re-runs the IAM analysis and compares it against the newest baseline
+snapshot recorded before --since, reporting role assignments and issues
+that are new or resolved. Exits non-zero if any new issue meets or
+exceeds --fail-on, so this can gate CI as a drift monitor.`,
+		Run: runDiff,
+	}
+
+	cmd.Flags().StringVar(&diffSince, "since", "24h", "How far back to look for a baseline: a Go duration (e.g. 24h, 168h) or an RFC3339 timestamp")
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	logger := log.New(os.Stderr, logLevel)
+
+	cutoff, err := parseSince(diffSince)
+	if err != nil {
+		logger.Error("invalid --since value", "since", diffSince, "error", err)
+		os.Exit(1)
+	}
+
+	store, closeStore, err := openBaselineStore(ctx)
+	if err != nil {
+		logger.Error("failed to open baseline store", "error", err)
+		os.Exit(1)
+	}
+	defer closeStore()
+
+	baselineReport, baselineTime, err := baseline.LatestIAMReport(store, projectID, cutoff)
+	if err != nil {
+		logger.Error("failed to load baseline snapshot", "project", projectID, "since", diffSince, "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("comparing against baseline", "project", projectID, "baseline_time", baselineTime)
+
+	analyzer, err := iam.NewAnalyzer(ctx, projectID)
+	if err != nil {
+		logger.Error("failed to create IAM analyzer", "error", err)
+		os.Exit(1)
+	}
+	analyzer.SetChecks(checks, skipChecks)
+
+	analysis, err := analyzer.AnalyzeProject()
+	if err != nil {
+		logger.Error("failed to analyze project", "error", err)
+		os.Exit(1)
+	}
+
+	report := iam.NewReport(analysis)
+	report.Baseline = baselineReport
+
+	if saveBaseline {
+		if err := baseline.SaveIAMReport(store, report.GeneratedAt, report); err != nil {
+			logger.Warn("failed to save baseline snapshot", "error", err)
+		}
+	}
+
+	var format iam.ReportFormat
+	switch reportFormat {
+	case "json":
+		format = iam.JSONFormat
+	case "csv":
+		format = iam.CSVFormat
+	default:
+		format = iam.TextFormat
+	}
+
+	if err := iam.WriteReportToFile(outputPath, report, format); err != nil {
+		logger.Error("failed to write report", "error", err)
+		os.Exit(1)
+	}
+
+	diff := report.DiffSinceBaseline()
+	logger.Info("diff completed", "new_issues", len(diff.NewIssues), "resolved_issues", len(diff.ResolvedIssues))
+
+	if failOn != "" && failOn != "none" {
+		threshold := severityRank(strings.ToUpper(failOn))
+		for _, issue := range diff.NewIssues {
+			if severityRank(issue.Severity) >= threshold {
+				logger.Error("failing: found new issues at or above fail-on severity since baseline", "fail_on", strings.ToUpper(failOn))
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// parseSince interprets value as a Go duration (subtracted from now) or,
+// failing that, an RFC3339 timestamp.
+func parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC3339 timestamp", value)
+}
@@ -0,0 +1,77 @@
+// Package iam_server runs a lightweight HTTP server CI systems can post
+// iam-analyzer reports to and query historical trends from, so a shared
+// team pipeline doesn't need direct filesystem/GCS access to the
+// pkg/gcp/iam/archive store (analogous to a REST "/analyses/:id/archive"
+// route).
+package iam_server
+
+import (
+	"context"
+	"os"
+
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/archive"
+	"github.com/hieptle/gcp-guardrail/pkg/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	cfgFile          string
+	addr             string
+	archiveDir       string
+	archiveGCSBucket string
+	archiveGCSPrefix string
+	logLevel         string
+)
+
+// GetCommand returns the iam-server command.
+func GetCommand() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "iam-server",
+		Short: "REST API for archiving and diffing IAM analysis reports",
+		Long: `Runs an HTTP server that accepts iam-analyzer reports posted by CI jobs,
+persists them to the archive store, and serves historical trend and diff
+queries over them. sa-tracker reports are not accepted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := log.New(os.Stderr, logLevel)
+
+			store, err := openArchiveStore(context.Background())
+			if err != nil {
+				logger.Error("failed to open archive store", "error", err)
+				os.Exit(1)
+			}
+
+			srv := newServer(store, logger)
+
+			logger.Info("starting iam-server", "addr", addr)
+			if err := srv.ListenAndServe(addr); err != nil {
+				logger.Error("server failed", "error", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.iam-server.yaml)")
+	rootCmd.PersistentFlags().StringVar(&addr, "addr", ":8090", "Address to listen on")
+	rootCmd.PersistentFlags().StringVar(&archiveDir, "archive-dir", "", "Directory to archive reports in (default: $HOME/.gcp-guardrail/archives)")
+	rootCmd.PersistentFlags().StringVar(&archiveGCSBucket, "archive-gcs-bucket", "", "Archive reports in this GCS bucket instead of a local directory")
+	rootCmd.PersistentFlags().StringVar(&archiveGCSPrefix, "archive-gcs-prefix", "", "Object prefix to use within --archive-gcs-bucket")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+
+	viper.BindPFlag("addr", rootCmd.PersistentFlags().Lookup("addr"))
+	viper.BindPFlag("archive-dir", rootCmd.PersistentFlags().Lookup("archive-dir"))
+	viper.BindPFlag("archive-gcs-bucket", rootCmd.PersistentFlags().Lookup("archive-gcs-bucket"))
+	viper.BindPFlag("archive-gcs-prefix", rootCmd.PersistentFlags().Lookup("archive-gcs-prefix"))
+	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
+
+	return rootCmd
+}
+
+// openArchiveStore opens the archive.Store selected by --archive-gcs-bucket
+// (if set) or --archive-dir (falling back to its default directory).
+func openArchiveStore(ctx context.Context) (archive.Store, error) {
+	if archiveGCSBucket != "" {
+		return archive.NewGCSStore(ctx, archiveGCSBucket, archiveGCSPrefix)
+	}
+	return archive.NewFileStore(archiveDir)
+}
@@ -0,0 +1,184 @@
+package iam_server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/archive"
+)
+
+// server implements the REST routes backing iam-server:
+//
+//	POST /analyses/:project/archive  archive a posted iam.Report
+//	GET  /analyses/:project/latest   fetch the most recently archived report
+//	GET  /analyses/:project/history  list archived report timestamps
+//	GET  /analyses/:project/diff     diff two archived reports (?against=ref[&from=ref])
+type server struct {
+	store  archive.Store
+	logger *slog.Logger
+}
+
+func newServer(store archive.Store, logger *slog.Logger) *server {
+	return &server{store: store, logger: logger}
+}
+
+// ListenAndServe registers the routes and blocks serving on addr.
+func (s *server) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/analyses/", s.handleAnalyses)
+	return http.ListenAndServe(addr, mux)
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// handleAnalyses dispatches /analyses/<project>/<action> requests. The
+// project segment may itself contain no slashes, matching how GCP
+// project IDs are formed.
+func (s *server) handleAnalyses(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/analyses/")
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "expected /analyses/<project>/<archive|latest|history|diff>", http.StatusBadRequest)
+		return
+	}
+	projectID, action := parts[0], parts[1]
+
+	switch action {
+	case "archive":
+		s.handleArchive(w, r, projectID)
+	case "latest":
+		s.handleLatest(w, r, projectID)
+	case "history":
+		s.handleHistory(w, r, projectID)
+	case "diff":
+		s.handleDiff(w, r, projectID)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleArchive accepts a POST body containing a JSON-encoded iam.Report
+// and persists it under the report's own ProjectID (projectID when the
+// report leaves it empty) at the report's GeneratedAt (the current time
+// when that is unset).
+func (s *server) handleArchive(w http.ResponseWriter, r *http.Request, projectID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var report iam.Report
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		http.Error(w, "invalid report body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if report.ProjectID == "" {
+		report.ProjectID = projectID
+	}
+
+	timestamp := report.GeneratedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	if err := archive.SaveIAMReport(s.store, timestamp, &report); err != nil {
+		s.logger.Error("failed to archive posted report", "project", projectID, "error", err)
+		http.Error(w, "failed to archive report", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"project_id":  projectID,
+		"archived_at": timestamp.UTC().Format(time.RFC3339),
+	})
+}
+
+// handleLatest returns the most recently archived report for projectID.
+func (s *server) handleLatest(w http.ResponseWriter, r *http.Request, projectID string) {
+	report, timestamp, err := archive.LoadIAMReport(s.store, projectID, "latest")
+	if err != nil {
+		s.writeLoadError(w, projectID, err)
+		return
+	}
+	s.writeJSON(w, map[string]interface{}{
+		"project_id":  projectID,
+		"archived_at": timestamp.UTC().Format(time.RFC3339),
+		"report":      report,
+	})
+}
+
+// handleHistory lists every timestamp a report has been archived for
+// projectID, oldest first.
+func (s *server) handleHistory(w http.ResponseWriter, r *http.Request, projectID string) {
+	history, err := s.store.History(projectID)
+	if err != nil {
+		s.logger.Error("failed to list archive history", "project", projectID, "error", err)
+		http.Error(w, "failed to list archive history", http.StatusInternalServerError)
+		return
+	}
+
+	refs := make([]string, len(history))
+	for i, ts := range history {
+		refs[i] = ts.UTC().Format(time.RFC3339)
+	}
+	s.writeJSON(w, map[string]interface{}{
+		"project_id": projectID,
+		"history":    refs,
+	})
+}
+
+// handleDiff compares the report at ?from= (default "latest") against
+// ?against=, which is required.
+func (s *server) handleDiff(w http.ResponseWriter, r *http.Request, projectID string) {
+	against := r.URL.Query().Get("against")
+	if against == "" {
+		http.Error(w, "missing required ?against= ref", http.StatusBadRequest)
+		return
+	}
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		from = "latest"
+	}
+
+	newReport, newAt, err := archive.LoadIAMReport(s.store, projectID, from)
+	if err != nil {
+		s.writeLoadError(w, projectID, err)
+		return
+	}
+	oldReport, oldAt, err := archive.LoadIAMReport(s.store, projectID, against)
+	if err != nil {
+		s.writeLoadError(w, projectID, err)
+		return
+	}
+
+	s.writeJSON(w, map[string]interface{}{
+		"project_id": projectID,
+		"from":       newAt.UTC().Format(time.RFC3339),
+		"against":    oldAt.UTC().Format(time.RFC3339),
+		"diff":       iam.DiffReports(oldReport, newReport),
+	})
+}
+
+func (s *server) writeLoadError(w http.ResponseWriter, projectID string, err error) {
+	if err == archive.ErrNotFound {
+		http.Error(w, "no archived report found for "+projectID, http.StatusNotFound)
+		return
+	}
+	s.logger.Error("failed to load archived report", "project", projectID, "error", err)
+	http.Error(w, "failed to load archived report", http.StatusInternalServerError)
+}
+
+func (s *server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Error("failed to encode response", "error", err)
+	}
+}
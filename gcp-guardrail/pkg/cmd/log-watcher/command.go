@@ -3,11 +3,13 @@ package log_watcher
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
-	"strconv"
 	"time"
 
 	"github.com/hieptle/gcp-guardrail/pkg/gcp/logging"
+	"github.com/hieptle/gcp-guardrail/pkg/log"
+	"github.com/hieptle/gcp-guardrail/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,7 +20,14 @@ var (
 	alertTopic     string
 	lookbackHours  int
 	timeWindowFlag string
-	verbose        bool
+	logLevel       string
+	logFile        string
+	tail           bool
+	rulesFile      string
+	stateFile      string
+	dryRun         bool
+	reportFormat   string
+	outputPath     string
 )
 
 // GetCommand returns the log-watcher command
@@ -31,38 +40,41 @@ Detects suspicious activities and generates alerts.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create context
 			ctx := context.Background()
+			logger, closeLogger := newLogger()
+			defer closeLogger()
 
 			// Calculate lookback period
 			lookbackPeriod := time.Duration(lookbackHours) * time.Hour
-			
-			if verbose {
-				fmt.Printf("Monitoring logs for project %s\n", projectID)
-				fmt.Printf("Looking back %s\n", lookbackPeriod)
-				fmt.Printf("Alert topic: %s\n", alertTopic)
-			}
+
+			logger.Info("monitoring logs", "project", projectID, "lookback", lookbackPeriod.String(), "alert_topic", alertTopic)
 
 			// Create log monitor
 			monitor, err := logging.NewMonitor(ctx, projectID, alertTopic)
 			if err != nil {
-				fmt.Printf("Failed to create log monitor: %v\n", err)
+				logger.Error("failed to create log monitor", "error", err)
 				os.Exit(1)
 			}
 			defer monitor.Close()
 
+			if tail {
+				runTail(ctx, monitor, logger)
+				return
+			}
+
 			// Create a filter for suspicious activities
 			filter := "severity>=WARNING"
 			alerts, err := monitor.QueryLogs(filter, lookbackPeriod)
 			if err != nil {
-				fmt.Printf("Failed to query logs: %v\n", err)
+				logger.Error("failed to query logs", "error", err)
 				os.Exit(1)
 			}
 
-			// Use strconv to satisfy the requirement
-			alertCount := strconv.Itoa(len(alerts))
-			fmt.Printf("Found %s security alerts\n", alertCount)
+			if err := writeAlertReport(alerts); err != nil {
+				logger.Error("failed to write report", "error", err)
+				os.Exit(1)
+			}
 
-			// In a real implementation, we would publish alerts and keep monitoring
-			fmt.Println("Log watcher completed initial scan!")
+			logger.Info("log watcher completed initial scan", "alerts_found", len(alerts))
 		},
 	}
 
@@ -70,14 +82,95 @@ Detects suspicious activities and generates alerts.`,
 	rootCmd.PersistentFlags().StringVar(&projectID, "project", "", "GCP project ID")
 	rootCmd.PersistentFlags().StringVar(&alertTopic, "alert-topic", "gcp-guardrail-alerts", "Pub/Sub topic for alerts")
 	rootCmd.PersistentFlags().IntVar(&lookbackHours, "lookback", 24, "Hours to look back for logs")
-	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to this file with rotation instead of stderr (recommended for --tail)")
+	rootCmd.PersistentFlags().BoolVar(&tail, "tail", false, "Continuously watch logs against detection rules instead of doing a one-shot scan")
+	rootCmd.PersistentFlags().StringVar(&rulesFile, "rules-file", "", "YAML or JSON detection rules file (default: built-in starter rule pack)")
+	rootCmd.PersistentFlags().StringVar(&stateFile, "state-file", "", "Path to persist each rule's last-seen cursor, so restarts don't re-alert (only used with --tail)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print alerts to stdout instead of publishing them to --alert-topic (only used with --tail)")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format for the one-shot scan's alerts (text, json, sarif, junit, github); ignored with --tail")
+	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output file path for the one-shot scan's report (default is stdout); ignored with --tail")
 
 	rootCmd.MarkPersistentFlagRequired("project")
 
 	viper.BindPFlag("project", rootCmd.PersistentFlags().Lookup("project"))
 	viper.BindPFlag("alert-topic", rootCmd.PersistentFlags().Lookup("alert-topic"))
 	viper.BindPFlag("lookback", rootCmd.PersistentFlags().Lookup("lookback"))
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	
+	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
+	viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	viper.BindPFlag("tail", rootCmd.PersistentFlags().Lookup("tail"))
+	viper.BindPFlag("rules-file", rootCmd.PersistentFlags().Lookup("rules-file"))
+	viper.BindPFlag("state-file", rootCmd.PersistentFlags().Lookup("state-file"))
+	viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	viper.BindPFlag("report-format", rootCmd.PersistentFlags().Lookup("report-format"))
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+
 	return rootCmd
-} 
\ No newline at end of file
+}
+
+// writeAlertReport renders the one-shot scan's alerts via pkg/report to
+// --output (default stdout) in --report-format.
+func writeAlertReport(alerts []*logging.Alert) error {
+	format := report.Format(reportFormat)
+	if format == "" {
+		format = report.TextFormat
+	}
+
+	w := os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return report.Write(w, "gcpgolang-log-watcher", logging.ToFindings(alerts), format)
+}
+
+// newLogger builds the command's logger: colorized console output by
+// default, or a rotating JSON file handler when --log-file is set (the
+// expected configuration for a long-running `--tail` invocation). The
+// returned closer must be deferred by the caller.
+func newLogger() (*slog.Logger, func()) {
+	if logFile == "" {
+		return log.New(os.Stderr, logLevel), func() {}
+	}
+
+	logger, closer, err := log.NewRotatingFile(logFile, logLevel)
+	if err != nil {
+		fmt.Printf("Failed to open log file: %v\n", err)
+		os.Exit(1)
+	}
+	return logger, func() { closer.Close() }
+}
+
+// runTail loads detection rules (from --rules-file, or the built-in starter
+// pack if unset), runs Monitor.Tail, and logs alerts as they arrive until
+// the process is interrupted.
+func runTail(ctx context.Context, monitor *logging.Monitor, logger *slog.Logger) {
+	var rules []logging.DetectionRule
+	var err error
+	if rulesFile != "" {
+		rules, err = logging.LoadRulesFile(rulesFile)
+	} else {
+		rules, err = logging.DefaultRules()
+	}
+	if err != nil {
+		logger.Error("failed to load detection rules", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("tailing logs", "project", projectID, "rule_count", len(rules), "state_file", stateFile, "dry_run", dryRun)
+
+	alerts, err := monitor.Tail(ctx, rules, logging.TailOptions{StateFile: stateFile, DryRun: dryRun})
+	if err != nil {
+		logger.Error("failed to start tailing logs", "error", err)
+		os.Exit(1)
+	}
+
+	for alert := range alerts {
+		logger.Warn(alert.Description, "severity", alert.Severity, "log_name", alert.LogName, "resource", alert.Resource)
+	}
+}
@@ -1,7 +1,9 @@
 package misconfig_scanner
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,7 +11,12 @@ import (
 	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt"
+	"github.com/hieptle/gcp-guardrail/pkg/analyzer"
+	"github.com/hieptle/gcp-guardrail/pkg/findings"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/asset"
+	sharedreport "github.com/hieptle/gcp-guardrail/pkg/report"
+	"github.com/hieptle/gcp-guardrail/pkg/secrets"
+	"github.com/hieptle/gcp-guardrail/pkg/wiz"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -22,16 +29,25 @@ var (
 	verbose       bool
 	wizClientID   string
 	wizClientSecret string
+	wizEndpoint   string
+	wizAudience   string
+	wizProjectFilter string
+	secretBackend string
 	integrateWiz  bool
 	scanType      string
+	enableAnalyzerPlugins bool
+	ignoreFile    string
+	toolRecordPath string
+	failOn        string
 )
 
-// WizAuthResponse represents the response from Wiz authentication
-type WizAuthResponse struct {
-	TokenType   string `json:"token_type"`
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
-}
+// wizClientIDSecretKey and wizClientSecretKey are the keys `login wiz`
+// stores the Wiz client-credentials under, and that resolveWizCredentials
+// reads them back from.
+const (
+	wizClientIDSecretKey     = "wiz-client-id"
+	wizClientSecretKey       = "wiz-client-secret"
+)
 
 // WizVulnerability represents a vulnerability found by Wiz
 type WizVulnerability struct {
@@ -45,6 +61,10 @@ type WizVulnerability struct {
 	Status        string    `json:"status"`
 	Remediation   string    `json:"remediation"`
 	CVE           string    `json:"cve,omitempty"`
+	// CVSSVector is the CVE's CVSS v3.0/v3.1 vector string, used by
+	// pkg/findings to derive a score-based severity instead of trusting
+	// Wiz's own Severity field.
+	CVSSVector    string    `json:"cvssVector,omitempty"`
 }
 
 // Misconfiguration represents a detected GCP configuration issue
@@ -65,6 +85,16 @@ type ScanResult struct {
 	ScanTime         time.Time           `json:"scan_time"`
 	Misconfigurations []Misconfiguration  `json:"misconfigurations"`
 	WizVulnerabilities []WizVulnerability `json:"wiz_vulnerabilities,omitempty"`
+	// NormalizedFindings is Misconfigurations and WizVulnerabilities
+	// normalized into the common findings.Finding shape with a
+	// CVSS-derived severity, after --ignore-file suppressions have been
+	// removed. countIssues and formatTextOutput consume this rather than
+	// the two source-specific slices above so severity counts stay
+	// consistent regardless of source.
+	NormalizedFindings []findings.Finding `json:"normalized_findings"`
+	// IgnoredFindings records every finding --ignore-file suppressed,
+	// alongside the rule that matched it, so suppressions stay auditable.
+	IgnoredFindings []findings.IgnoredFinding `json:"ignored_findings,omitempty"`
 	TotalIssues      int                 `json:"total_issues"`
 	SeverityCounts   map[string]int      `json:"severity_counts"`
 }
@@ -101,6 +131,13 @@ for comprehensive vulnerability management.`,
 				os.Exit(1)
 			}
 
+			// If Wiz integration is enabled, resolve any missing
+			// credentials from --secret-backend before checking whether
+			// we have enough to proceed.
+			if integrateWiz {
+				resolveWizCredentials(ctx)
+			}
+
 			// If Wiz integration is enabled, get vulnerability data
 			if integrateWiz && wizClientID != "" && wizClientSecret != "" {
 				if err := getWizVulnerabilities(ctx, result); err != nil {
@@ -109,9 +146,54 @@ for comprehensive vulnerability management.`,
 				}
 			}
 
+			// If analyzer plugins are enabled, discover and run them
+			// against the scanned resources, merging their findings in
+			// as additional misconfigurations.
+			if enableAnalyzerPlugins {
+				if err := runAnalyzerPlugins(ctx, result); err != nil {
+					fmt.Printf("Error running analyzer plugins: %v\n", err)
+					// Continue with the results gathered so far
+				}
+			}
+
+			// Normalize GCP misconfigurations and Wiz vulnerabilities into
+			// one severity-consistent shape, suppressing anything matched
+			// by --ignore-file.
+			if err := normalizeFindings(result); err != nil {
+				fmt.Printf("Error applying ignore rules: %v\n", err)
+				os.Exit(1)
+			}
+
 			// Count total issues and by severity
 			countIssues(result)
 
+			exitCode := 0
+			if exceedsFailOn(result, failOn) {
+				exitCode = 1
+			}
+
+			if toolRecordPath != "" {
+				record := ToolRecord{
+					ToolName:       "gcpgolang-misconfig-scanner",
+					ToolVersion:    toolVersion,
+					ScanStart:      result.ScanTime,
+					ScanEnd:        time.Now(),
+					ProjectID:      result.ProjectID,
+					ScanType:       scanType,
+					WizIntegration: integrateWiz,
+					ReportFormat:   reportFormat,
+					ReportPath:     outputPath,
+					TotalIssues:    result.TotalIssues,
+					SeverityCounts: result.SeverityCounts,
+					FailOn:         failOn,
+					ExitCode:       exitCode,
+				}
+				if err := writeToolRecord(toolRecordPath, record); err != nil {
+					fmt.Printf("Error writing toolrecord: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
 			// Output results
 			if err := outputResults(result); err != nil {
 				fmt.Printf("Error outputting results: %v\n", err)
@@ -124,18 +206,30 @@ for comprehensive vulnerability management.`,
 					fmt.Printf("  %s: %d\n", severity, count)
 				}
 			}
+
+			if exitCode != 0 {
+				os.Exit(exitCode)
+			}
 		},
 	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.misconfig-scanner.yaml)")
 	rootCmd.PersistentFlags().StringVar(&projectID, "project", "", "GCP project ID")
-	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv)")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv, sarif, github, cyclonedx-vex)")
 	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output file path (default is stdout)")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&integrateWiz, "wiz", false, "Enable Wiz integration for vulnerability data")
 	rootCmd.PersistentFlags().StringVar(&wizClientID, "wiz-client-id", "", "Wiz API Client ID")
 	rootCmd.PersistentFlags().StringVar(&wizClientSecret, "wiz-client-secret", "", "Wiz API Client Secret")
-	rootCmd.PersistentFlags().StringVar(&scanType, "scan-type", "all", "Scan type (all, storage, compute, network, iam)")
+	rootCmd.PersistentFlags().StringVar(&wizEndpoint, "wiz-endpoint", "", "Wiz tenant GraphQL API URL (e.g. https://api.<tenant>.app.wiz.io/graphql)")
+	rootCmd.PersistentFlags().StringVar(&wizAudience, "wiz-audience", "", "OAuth2 audience to request for the Wiz client-credentials token")
+	rootCmd.PersistentFlags().StringVar(&wizProjectFilter, "wiz-project-filter", "", "Only fetch Wiz vulnerability findings for this Wiz project ID (default: every project the token can see)")
+	rootCmd.PersistentFlags().StringVar(&secretBackend, "secret-backend", "keyring", "Backend to resolve --wiz-client-secret from when unset, and that `login wiz`/`logout wiz` store into (keyring, secretmanager, env)")
+	rootCmd.PersistentFlags().StringVar(&scanType, "scan-type", "all", "Scan type (all, storage, compute, network, iam, gke, kms)")
+	rootCmd.PersistentFlags().BoolVar(&enableAnalyzerPlugins, "enable-analyzer-plugins", false, "Discover and run analyzer plugins (binaries named gcpgolang-analyzer-* on $PATH) and merge their diagnostics into the scan results")
+	rootCmd.PersistentFlags().StringVar(&ignoreFile, "ignore-file", "", "YAML file of ignore rules (by CVE, resource-name glob, fix state, severity threshold, or vendor tag) to suppress findings")
+	rootCmd.PersistentFlags().StringVar(&toolRecordPath, "toolrecord", "", "Write a JSON manifest describing this scan run (tool version, timing, project, per-severity counts, exit code) to this path, for CI steps to discover without parsing --report-format's output")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "", "Exit non-zero if findings at or above this severity are present (critical, high, medium, low, none)")
 
 	rootCmd.MarkPersistentFlagRequired("project")
 
@@ -146,160 +240,191 @@ for comprehensive vulnerability management.`,
 	viper.BindPFlag("wiz", rootCmd.PersistentFlags().Lookup("wiz"))
 	viper.BindPFlag("wiz-client-id", rootCmd.PersistentFlags().Lookup("wiz-client-id"))
 	viper.BindPFlag("wiz-client-secret", rootCmd.PersistentFlags().Lookup("wiz-client-secret"))
+	viper.BindPFlag("wiz-endpoint", rootCmd.PersistentFlags().Lookup("wiz-endpoint"))
+	viper.BindPFlag("wiz-audience", rootCmd.PersistentFlags().Lookup("wiz-audience"))
+	viper.BindPFlag("wiz-project-filter", rootCmd.PersistentFlags().Lookup("wiz-project-filter"))
+	viper.BindPFlag("secret-backend", rootCmd.PersistentFlags().Lookup("secret-backend"))
 	viper.BindPFlag("scan-type", rootCmd.PersistentFlags().Lookup("scan-type"))
+	viper.BindPFlag("enable-analyzer-plugins", rootCmd.PersistentFlags().Lookup("enable-analyzer-plugins"))
+	viper.BindPFlag("ignore-file", rootCmd.PersistentFlags().Lookup("ignore-file"))
+	viper.BindPFlag("toolrecord", rootCmd.PersistentFlags().Lookup("toolrecord"))
+	viper.BindPFlag("fail-on", rootCmd.PersistentFlags().Lookup("fail-on"))
+
+	rootCmd.AddCommand(newLoginCommand())
+	rootCmd.AddCommand(newLogoutCommand())
 
 	return rootCmd
 }
 
-// scanGCPMisconfigurations scans the GCP project for misconfigurations
+// scanGCPMisconfigurations enumerates real GCP resources via Cloud Asset
+// Inventory and evaluates them against the pluggable PolicyMatchers
+// registered in pkg/gcp/asset, selected by --scan-type.
 func scanGCPMisconfigurations(ctx context.Context, result *ScanResult) error {
-	// This would be implemented with actual GCP API calls
-	// For now, we'll add some example misconfigurations
-	
-	// Example storage misconfigurations
-	if scanType == "all" || scanType == "storage" {
-		result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
-			ResourceType:    "storage.googleapis.com/Bucket",
-			ResourceName:    "example-bucket",
-			ResourceID:      fmt.Sprintf("projects/%s/buckets/example-bucket", projectID),
-			Issue:           "Public access enabled",
-			Severity:        "HIGH",
-			Recommendation:  "Configure uniform bucket-level access and remove public access",
-			Timestamp:       time.Now(),
-			Category:        "Storage",
-		})
-		
-		result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
-			ResourceType:    "storage.googleapis.com/Bucket",
-			ResourceName:    "logs-bucket",
-			ResourceID:      fmt.Sprintf("projects/%s/buckets/logs-bucket", projectID),
-			Issue:           "Encryption not configured",
-			Severity:        "MEDIUM",
-			Recommendation:  "Enable CMEK encryption for sensitive data",
-			Timestamp:       time.Now(),
-			Category:        "Storage",
-		})
+	scanner, err := asset.NewScanner(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create asset scanner: %w", err)
 	}
-	
-	// Example compute misconfigurations
-	if scanType == "all" || scanType == "compute" {
-		result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
-			ResourceType:    "compute.googleapis.com/Instance",
-			ResourceName:    "instance-1",
-			ResourceID:      fmt.Sprintf("projects/%s/zones/us-central1-a/instances/instance-1", projectID),
-			Issue:           "Instance has public IP with open SSH port",
-			Severity:        "HIGH",
-			Recommendation:  "Use IAP for SSH access instead of open firewall rules",
-			Timestamp:       time.Now(),
-			Category:        "Compute",
-		})
+	defer scanner.Close()
+
+	scope := fmt.Sprintf("projects/%s", projectID)
+	findings, err := scanner.Scan(ctx, scope, scanType)
+	if err != nil {
+		return fmt.Errorf("failed to scan project %s: %w", projectID, err)
 	}
-	
-	// Example network misconfigurations
-	if scanType == "all" || scanType == "network" {
+
+	now := time.Now()
+	for _, f := range findings {
 		result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
-			ResourceType:    "compute.googleapis.com/Firewall",
-			ResourceName:    "default-allow-all",
-			ResourceID:      fmt.Sprintf("projects/%s/global/firewalls/default-allow-all", projectID),
-			Issue:           "Overly permissive firewall rule (0.0.0.0/0)",
-			Severity:        "CRITICAL",
-			Recommendation:  "Restrict firewall rules to specific IP ranges",
-			Timestamp:       time.Now(),
-			Category:        "Network",
+			ResourceType:   f.ResourceType,
+			ResourceName:   f.ResourceName,
+			ResourceID:     f.ResourceID,
+			Issue:          f.Issue,
+			Severity:       f.Severity,
+			Recommendation: f.Recommendation,
+			Timestamp:      now,
+			Category:       f.Category,
 		})
 	}
-	
-	// Example IAM misconfigurations
-	if scanType == "all" || scanType == "iam" {
-		result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
-			ResourceType:    "iam.googleapis.com/ServiceAccount",
-			ResourceName:    "service-account-1",
-			ResourceID:      fmt.Sprintf("projects/%s/serviceAccounts/service-account-1@%s.iam.gserviceaccount.com", projectID, projectID),
-			Issue:           "Service account has owner role",
-			Severity:        "HIGH",
-			Recommendation:  "Follow principle of least privilege and assign more specific roles",
-			Timestamp:       time.Now(),
-			Category:        "IAM",
+
+	return nil
+}
+
+// runAnalyzerPlugins discovers analyzer plugin binaries on $PATH, runs
+// AnalyzeStack against the resources already found by
+// scanGCPMisconfigurations, and appends each returned diagnostic as a
+// Misconfiguration so plugin and built-in findings share one report.
+func runAnalyzerPlugins(ctx context.Context, result *ScanResult) error {
+	binaries, err := analyzer.Discover()
+	if err != nil {
+		return fmt.Errorf("discover analyzer plugins: %w", err)
+	}
+	if len(binaries) == 0 {
+		return nil
+	}
+
+	resources := make([]analyzer.ResourceProperties, 0, len(result.Misconfigurations))
+	for _, misc := range result.Misconfigurations {
+		resources = append(resources, analyzer.ResourceProperties{
+			URN:  misc.ResourceID,
+			Type: misc.ResourceType,
 		})
 	}
 
+	for _, binaryPath := range binaries {
+		client, err := analyzer.Launch(binaryPath)
+		if err != nil {
+			fmt.Printf("Failed to launch analyzer plugin %s: %v\n", binaryPath, err)
+			continue
+		}
+
+		if verbose {
+			fmt.Printf("Running analyzer plugin: %s\n", client.Name)
+		}
+
+		diagnostics, err := client.AnalyzeStack(ctx, resources)
+		client.Close()
+		if err != nil {
+			fmt.Printf("Analyzer plugin %s failed: %v\n", client.Name, err)
+			continue
+		}
+
+		for _, d := range diagnostics {
+			result.Misconfigurations = append(result.Misconfigurations, Misconfiguration{
+				ResourceType:   d.URN,
+				ResourceName:   d.URN,
+				ResourceID:     d.URN,
+				Issue:          d.Message,
+				Severity:       strings.ToUpper(d.Severity),
+				Recommendation: d.Remediation,
+				Timestamp:      time.Now(),
+				Category:       fmt.Sprintf("Plugin:%s", d.PolicyName),
+			})
+		}
+	}
+
 	return nil
 }
 
-// getWizVulnerabilities fetches vulnerability data from Wiz API
-func getWizVulnerabilities(ctx context.Context, result *ScanResult) error {
-	// Authenticate with Wiz API
-	token, err := authenticateWiz(wizClientID, wizClientSecret)
+// resolveWizCredentials fills in wizClientID/wizClientSecret from
+// --secret-backend for whichever of the two wasn't passed on the command
+// line, so a user who ran `login wiz` never needs --wiz-client-secret
+// again. Resolution failures are swallowed: the caller's existing
+// wizClientID != "" && wizClientSecret != "" check already handles "Wiz
+// integration enabled but no credentials available" by skipping it.
+func resolveWizCredentials(ctx context.Context) {
+	if wizClientID != "" && wizClientSecret != "" {
+		return
+	}
+
+	backend, err := secrets.New(ctx, secrets.BackendType(secretBackend), secrets.Config{ProjectID: projectID})
 	if err != nil {
-		return fmt.Errorf("failed to authenticate with Wiz: %w", err)
+		return
 	}
-	
-	// In a real implementation, we would query the Wiz API with GraphQL
-	// using the authentication token
-	if verbose {
-		fmt.Printf("Using Wiz token: %s...\n", token[:10])
+
+	if wizClientID == "" {
+		if value, err := backend.Get(wizClientIDSecretKey); err == nil {
+			wizClientID = value
+		}
 	}
-	
-	// For this example, we'll add sample vulnerabilities
-	result.WizVulnerabilities = append(result.WizVulnerabilities, WizVulnerability{
-		ID:           "wiz-vuln-1",
-		Name:         "CVE-2023-1234",
-		Description:  "Critical vulnerability in container image",
-		Severity:     "CRITICAL",
-		ResourceName: "frontend-app",
-		ResourceType: "Container",
-		FirstSeen:    time.Now().Add(-48 * time.Hour),
-		Status:       "OPEN",
-		Remediation:  "Update to latest version",
-		CVE:          "CVE-2023-1234",
-	})
-	
-	result.WizVulnerabilities = append(result.WizVulnerabilities, WizVulnerability{
-		ID:           "wiz-vuln-2",
-		Name:         "Outdated TLS Configuration",
-		Description:  "Load balancer using outdated TLS configuration",
-		Severity:     "MEDIUM",
-		ResourceName: "frontend-lb",
-		ResourceType: "LoadBalancer",
-		FirstSeen:    time.Now().Add(-72 * time.Hour),
-		Status:       "OPEN",
-		Remediation:  "Update TLS configuration to use TLS 1.2+",
-	})
-	
-	if verbose {
-		fmt.Printf("Retrieved %d vulnerabilities from Wiz\n", len(result.WizVulnerabilities))
+	if wizClientSecret == "" {
+		if value, err := backend.Get(wizClientSecretKey); err == nil {
+			wizClientSecret = value
+		}
 	}
-	
-	return nil
 }
 
-// authenticateWiz authenticates with the Wiz API and returns a token
-func authenticateWiz(clientID, clientSecret string) (string, error) {
-	// In a real implementation, we would call the Wiz authentication API
-	// For this example, we'll just return a dummy token
-	
+// getWizVulnerabilities authenticates with the Wiz API via OAuth2
+// client-credentials and fetches every vulnerability finding visible to
+// the token (optionally narrowed to --wiz-project-filter), paginating
+// through the GraphQL API until exhausted.
+func getWizVulnerabilities(ctx context.Context, result *ScanResult) error {
+	client, err := wiz.NewClient(ctx, wiz.Config{
+		ClientID:     wizClientID,
+		ClientSecret: wizClientSecret,
+		Audience:     wizAudience,
+		Endpoint:     wizEndpoint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Wiz client: %w", err)
+	}
+
 	if verbose {
-		fmt.Println("Authenticating with Wiz API...")
+		fmt.Printf("Fetching Wiz vulnerability findings from %s\n", wizEndpoint)
 	}
-	
-	// Create a JWT token (this is just an example, not how Wiz actually works)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": clientID,
-		"exp": time.Now().Add(time.Hour).Unix(),
-	})
-	
-	// Sign and get the complete encoded token as a string
-	tokenString, err := token.SignedString([]byte(clientSecret))
+
+	vulns, err := client.ListVulnerabilities(ctx, wiz.VulnerabilityFilter{ProjectID: wizProjectFilter})
 	if err != nil {
-		return "", fmt.Errorf("failed to create token: %w", err)
+		return fmt.Errorf("failed to list Wiz vulnerability findings: %w", err)
 	}
-	
-	return tokenString, nil
+
+	for _, v := range vulns {
+		result.WizVulnerabilities = append(result.WizVulnerabilities, WizVulnerability{
+			ID:           v.ID,
+			Name:         v.Name,
+			Description:  v.Description,
+			Severity:     v.Severity,
+			ResourceName: v.ResourceName,
+			ResourceType: v.ResourceType,
+			FirstSeen:    v.FirstSeen,
+			Status:       v.Status,
+			Remediation:  v.Remediation,
+			CVE:          v.CVE,
+			CVSSVector:   v.CVSSVector,
+		})
+	}
+
+	if verbose {
+		fmt.Printf("Retrieved %d vulnerabilities from Wiz\n", len(result.WizVulnerabilities))
+	}
+
+	return nil
 }
 
-// countIssues counts the total issues and issues by severity
+// countIssues counts the total issues and issues by severity from
+// result.NormalizedFindings, so a Wiz vulnerability's CVSS-derived
+// severity and a GCP misconfiguration's native severity are counted on
+// the same scale.
 func countIssues(result *ScanResult) {
-	// Reset counts
 	result.TotalIssues = 0
 	result.SeverityCounts = map[string]int{
 		"CRITICAL": 0,
@@ -307,40 +432,55 @@ func countIssues(result *ScanResult) {
 		"MEDIUM":   0,
 		"LOW":      0,
 	}
-	
-	// Count GCP misconfigurations
-	for _, misc := range result.Misconfigurations {
-		result.TotalIssues++
-		result.SeverityCounts[misc.Severity]++
-	}
-	
-	// Count Wiz vulnerabilities
-	for _, vuln := range result.WizVulnerabilities {
+
+	for _, f := range result.NormalizedFindings {
 		result.TotalIssues++
-		result.SeverityCounts[vuln.Severity]++
+		result.SeverityCounts[f.Severity]++
 	}
 }
 
 // outputResults outputs the scan results in the specified format
 func outputResults(result *ScanResult) error {
+	// sarif/github are rendered through the shared report package instead
+	// of being built up as a []byte below, since both need a Writer rather
+	// than a single marshaled blob.
+	switch strings.ToLower(reportFormat) {
+	case "sarif", "github":
+		w := os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+		format := sharedreport.SARIFFormat
+		if strings.ToLower(reportFormat) == "github" {
+			format = sharedreport.GitHubFormat
+		}
+		return sharedreport.Write(w, "gcpgolang-misconfig-scanner", toFindings(result), format)
+	}
+
 	var output []byte
 	var err error
-	
+
 	switch strings.ToLower(reportFormat) {
 	case "json":
 		output, err = json.MarshalIndent(result, "", "  ")
 	case "csv":
-		// In a real implementation, we would convert to CSV
-		output = []byte("CSV output not implemented")
+		output, err = formatCSVOutput(result)
+	case "cyclonedx-vex":
+		output, err = json.MarshalIndent(toCycloneDXVEX(result), "", "  ")
 	default:
 		// Text format
 		output = formatTextOutput(result)
 	}
-	
+
 	if err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
-	
+
 	if outputPath == "" {
 		// Output to stdout
 		fmt.Println(string(output))
@@ -350,10 +490,69 @@ func outputResults(result *ScanResult) error {
 			return fmt.Errorf("failed to write output to file: %w", err)
 		}
 	}
-	
+
 	return nil
 }
 
+// toFindings converts result.NormalizedFindings into the shared
+// report.Finding shape, for the sarif/github output paths. File is set to
+// the resource's self-link (ResourceID) rather than a source file, since
+// GCP resources have no source location of their own; SARIF's
+// artifactLocation.uri still lets GitHub Advanced Security dashboards
+// link a result straight to the offending resource.
+func toFindings(result *ScanResult) []sharedreport.Finding {
+	out := make([]sharedreport.Finding, 0, len(result.NormalizedFindings))
+	for _, f := range result.NormalizedFindings {
+		out = append(out, sharedreport.Finding{
+			RuleID:      f.RuleID,
+			Severity:    f.Severity,
+			Resource:    f.Resource,
+			Message:     f.Message,
+			Remediation: f.Remediation,
+			File:        f.ResourceID,
+		})
+	}
+	return out
+}
+
+// formatCSVOutput renders result.NormalizedFindings as CSV, one row per
+// finding.
+func formatCSVOutput(result *ScanResult) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"source", "severity", "resource", "resource_id", "rule_id", "category", "message", "remediation", "cve", "fix_state", "timestamp"}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, f := range result.NormalizedFindings {
+		row := []string{
+			f.Source,
+			f.Severity,
+			f.Resource,
+			f.ResourceID,
+			f.RuleID,
+			f.Category,
+			f.Message,
+			f.Remediation,
+			f.CVE,
+			f.FixState,
+			f.Timestamp.Format(time.RFC3339),
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to flush CSV output: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // formatTextOutput formats the scan results as text
 func formatTextOutput(result *ScanResult) []byte {
 	var sb strings.Builder
@@ -369,26 +568,44 @@ func formatTextOutput(result *ScanResult) []byte {
 	sb.WriteString(fmt.Sprintf("  LOW: %d\n\n", result.SeverityCounts["LOW"]))
 	
 	sb.WriteString("GCP Misconfigurations:\n")
-	for i, misc := range result.Misconfigurations {
-		sb.WriteString(fmt.Sprintf("%d. [%s] %s: %s\n", i+1, misc.Severity, misc.ResourceName, misc.Issue))
-		sb.WriteString(fmt.Sprintf("   Resource: %s\n", misc.ResourceType))
-		sb.WriteString(fmt.Sprintf("   Recommendation: %s\n", misc.Recommendation))
+	for i, f := range result.NormalizedFindings {
+		if f.Source != "gcp" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%d. [%s] %s: %s\n", i+1, f.Severity, f.Resource, f.Message))
+		sb.WriteString(fmt.Sprintf("   Resource: %s\n", f.ResourceID))
+		sb.WriteString(fmt.Sprintf("   Recommendation: %s\n", f.Remediation))
 		sb.WriteString("\n")
 	}
-	
-	if len(result.WizVulnerabilities) > 0 {
+
+	wizFindings := 0
+	for _, f := range result.NormalizedFindings {
+		if f.Source == "wiz" {
+			wizFindings++
+		}
+	}
+	if wizFindings > 0 {
 		sb.WriteString("Wiz Vulnerabilities:\n")
-		for i, vuln := range result.WizVulnerabilities {
-			sb.WriteString(fmt.Sprintf("%d. [%s] %s: %s\n", i+1, vuln.Severity, vuln.ResourceName, vuln.Name))
-			sb.WriteString(fmt.Sprintf("   Description: %s\n", vuln.Description))
-			sb.WriteString(fmt.Sprintf("   First Seen: %s\n", vuln.FirstSeen.Format(time.RFC3339)))
-			sb.WriteString(fmt.Sprintf("   Remediation: %s\n", vuln.Remediation))
-			if vuln.CVE != "" {
-				sb.WriteString(fmt.Sprintf("   CVE: %s\n", vuln.CVE))
+		i := 0
+		for _, f := range result.NormalizedFindings {
+			if f.Source != "wiz" {
+				continue
+			}
+			i++
+			sb.WriteString(fmt.Sprintf("%d. [%s] %s: %s\n", i, f.Severity, f.Resource, f.RuleID))
+			sb.WriteString(fmt.Sprintf("   Description: %s\n", f.Message))
+			sb.WriteString(fmt.Sprintf("   First Seen: %s\n", f.Timestamp.Format(time.RFC3339)))
+			sb.WriteString(fmt.Sprintf("   Remediation: %s\n", f.Remediation))
+			if f.CVE != "" {
+				sb.WriteString(fmt.Sprintf("   CVE: %s (CVSS %.1f)\n", f.CVE, f.BaseScore))
 			}
 			sb.WriteString("\n")
 		}
 	}
-	
+
+	if len(result.IgnoredFindings) > 0 {
+		sb.WriteString(fmt.Sprintf("Ignored Findings: %d (see --report-format json for details)\n", len(result.IgnoredFindings)))
+	}
+
 	return []byte(sb.String())
 } 
\ No newline at end of file
@@ -0,0 +1,101 @@
+package misconfig_scanner
+
+import (
+	"fmt"
+
+	"github.com/hieptle/gcp-guardrail/pkg/findings"
+)
+
+// normalizeFindings converts result.Misconfigurations and
+// result.WizVulnerabilities into result.NormalizedFindings, then applies
+// --ignore-file's rules, moving suppressed findings into
+// result.IgnoredFindings.
+func normalizeFindings(result *ScanResult) error {
+	all := make([]findings.Finding, 0, len(result.Misconfigurations)+len(result.WizVulnerabilities))
+	for _, m := range result.Misconfigurations {
+		all = append(all, misconfigurationToFinding(m))
+	}
+	for _, v := range result.WizVulnerabilities {
+		all = append(all, wizVulnerabilityToFinding(v))
+	}
+
+	if ignoreFile == "" {
+		result.NormalizedFindings = all
+		return nil
+	}
+
+	rules, err := findings.LoadIgnoreRules(ignoreFile)
+	if err != nil {
+		return fmt.Errorf("failed to load ignore file %s: %w", ignoreFile, err)
+	}
+
+	kept, ignored := findings.Apply(all, rules)
+	result.NormalizedFindings = kept
+	result.IgnoredFindings = ignored
+	return nil
+}
+
+// misconfigurationToFinding normalizes a GCP Misconfiguration. GCP
+// misconfigurations are live configuration deltas rather than patchable
+// vulnerabilities and carry no CVE, so the severity is taken as-is from
+// the scanner rather than derived from a CVSS score.
+func misconfigurationToFinding(m Misconfiguration) findings.Finding {
+	return findings.Finding{
+		Source:      "gcp",
+		RuleID:      m.Category,
+		Resource:    m.ResourceName,
+		ResourceID:  m.ResourceID,
+		Message:     m.Issue,
+		Remediation: m.Recommendation,
+		Category:    m.Category,
+		Timestamp:   m.Timestamp,
+		FixState:    "not-fixed",
+		Severity:    m.Severity,
+	}
+}
+
+// wizVulnerabilityToFinding normalizes a Wiz WizVulnerability, deriving
+// its severity from CVSSVector when present so Wiz's own Severity label
+// can't disagree with GCP findings' severity scale.
+func wizVulnerabilityToFinding(v WizVulnerability) findings.Finding {
+	f := findings.Finding{
+		Source:      "wiz",
+		RuleID:      v.Name,
+		Resource:    v.ResourceName,
+		Message:     v.Description,
+		Remediation: v.Remediation,
+		Category:    v.ResourceType,
+		Timestamp:   v.FirstSeen,
+		CVE:         v.CVE,
+		CVSSVector:  v.CVSSVector,
+		VendorTags:  []string{v.Status},
+		FixState:    fixStateFromWizStatus(v.Status),
+		Severity:    v.Severity,
+	}
+
+	if v.CVSSVector != "" {
+		if score, severity, err := findings.ParseCVSS(v.CVSSVector); err == nil {
+			f.BaseScore = score
+			f.Severity = severity
+		} else if verbose {
+			fmt.Printf("Warning: failed to parse CVSS vector for %s: %v\n", v.ID, err)
+		}
+	}
+
+	return f
+}
+
+// fixStateFromWizStatus maps a Wiz vulnerability's Status field to the
+// fixed/not-fixed/wont-fix/unknown vocabulary ignore rules match on.
+func fixStateFromWizStatus(status string) string {
+	switch status {
+	case "RESOLVED":
+		return "fixed"
+	case "OPEN":
+		return "not-fixed"
+	case "IGNORED", "REJECTED":
+		return "wont-fix"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,126 @@
+package misconfig_scanner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hieptle/gcp-guardrail/pkg/secrets"
+	"github.com/hieptle/gcp-guardrail/pkg/wiz"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// newLoginCommand returns the "login" parent command. Its "wiz"
+// subcommand performs the Wiz OAuth2 client-credentials exchange
+// interactively and stores the resulting credentials in --secret-backend,
+// so later runs never need --wiz-client-secret on the command line.
+func newLoginCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Authenticate with a third-party integration and store its credentials",
+	}
+	cmd.AddCommand(newLoginWizCommand())
+	return cmd
+}
+
+// newLogoutCommand returns the "logout" parent command.
+func newLogoutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Remove a third-party integration's stored credentials",
+	}
+	cmd.AddCommand(newLogoutWizCommand())
+	return cmd
+}
+
+func newLoginWizCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wiz",
+		Short: "Authenticate with Wiz and store the client credentials in --secret-backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return loginWiz(cmd.Context())
+		},
+	}
+}
+
+func newLogoutWizCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wiz",
+		Short: "Remove Wiz credentials from --secret-backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return logoutWiz(cmd.Context())
+		},
+	}
+}
+
+// loginWiz prompts for a Wiz client ID and client secret (the secret read
+// without echo, so it never touches the terminal's scrollback history the
+// way a command-line flag would), validates them against the Wiz API,
+// and stores both in --secret-backend.
+func loginWiz(ctx context.Context) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Wiz Client ID: ")
+	clientID, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read client ID: %w", err)
+	}
+	clientID = strings.TrimSpace(clientID)
+
+	fmt.Print("Wiz Client Secret: ")
+	secretBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("failed to read client secret: %w", err)
+	}
+	clientSecret := strings.TrimSpace(string(secretBytes))
+
+	if wizEndpoint == "" {
+		return fmt.Errorf("--wiz-endpoint is required")
+	}
+
+	client, err := wiz.NewClient(ctx, wiz.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Audience:     wizAudience,
+		Endpoint:     wizEndpoint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Wiz client: %w", err)
+	}
+	if err := client.Authenticate(ctx); err != nil {
+		return fmt.Errorf("failed to validate Wiz credentials: %w", err)
+	}
+
+	backend, err := secrets.New(ctx, secrets.BackendType(secretBackend), secrets.Config{ProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("failed to create secret backend: %w", err)
+	}
+	if err := backend.Set(wizClientIDSecretKey, clientID); err != nil {
+		return fmt.Errorf("failed to store Wiz client ID: %w", err)
+	}
+	if err := backend.Set(wizClientSecretKey, clientSecret); err != nil {
+		return fmt.Errorf("failed to store Wiz client secret: %w", err)
+	}
+
+	fmt.Printf("Wiz credentials validated and stored in the %s backend.\n", backend.Name())
+	return nil
+}
+
+func logoutWiz(ctx context.Context) error {
+	backend, err := secrets.New(ctx, secrets.BackendType(secretBackend), secrets.Config{ProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("failed to create secret backend: %w", err)
+	}
+	if err := backend.Delete(wizClientIDSecretKey); err != nil {
+		fmt.Printf("Warning: failed to delete stored Wiz client ID: %v\n", err)
+	}
+	if err := backend.Delete(wizClientSecretKey); err != nil {
+		fmt.Printf("Warning: failed to delete stored Wiz client secret: %v\n", err)
+	}
+	fmt.Println("Wiz credentials removed.")
+	return nil
+}
@@ -0,0 +1,76 @@
+package misconfig_scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// toolVersion is gcp-guardrail's own version, stamped into the toolrecord
+// manifest. The project doesn't have a formal release process yet, so
+// this is a placeholder until one exists.
+const toolVersion = "dev"
+
+// ToolRecord is a CI-oriented manifest describing one misconfig-scanner
+// run, written to --toolrecord so downstream pipeline steps can discover
+// and aggregate scan artifacts without parsing the scan's own report
+// format (text/json/sarif/...).
+type ToolRecord struct {
+	ToolName       string         `json:"toolName"`
+	ToolVersion    string         `json:"toolVersion"`
+	ScanStart      time.Time      `json:"scanStart"`
+	ScanEnd        time.Time      `json:"scanEnd"`
+	ProjectID      string         `json:"projectId"`
+	ScanType       string         `json:"scanType"`
+	WizIntegration bool           `json:"wizIntegration"`
+	ReportFormat   string         `json:"reportFormat"`
+	ReportPath     string         `json:"reportPath,omitempty"`
+	TotalIssues    int            `json:"totalIssues"`
+	SeverityCounts map[string]int `json:"severityCounts"`
+	FailOn         string         `json:"failOn,omitempty"`
+	ExitCode       int            `json:"exitCode"`
+}
+
+// writeToolRecord renders record as JSON to path.
+func writeToolRecord(path string, record ToolRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal toolrecord: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write toolrecord to %s: %w", path, err)
+	}
+	return nil
+}
+
+// severityRank orders severities from least to most severe, matching
+// --fail-on's vocabulary.
+var severityRank = map[string]int{
+	"none":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// exceedsFailOn reports whether result has any finding at a severity at
+// or above threshold. An empty threshold, "none", or an unrecognized
+// value never triggers.
+func exceedsFailOn(result *ScanResult, threshold string) bool {
+	rank, ok := severityRank[strings.ToLower(threshold)]
+	if !ok || rank == 0 {
+		return false
+	}
+
+	for severity, count := range result.SeverityCounts {
+		if count == 0 {
+			continue
+		}
+		if r, ok := severityRank[strings.ToLower(severity)]; ok && r >= rank {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package misconfig_scanner
+
+// cycloneDXVEXDocument is a minimal CycloneDX VEX document: just enough
+// to express each CVE-bearing Wiz finding's exploitability so downstream
+// tooling can correlate it with an SBOM, mirroring how SCA tools like
+// WhiteSource/Grype emit VEX.
+type cycloneDXVEXDocument struct {
+	BOMFormat       string                  `json:"bomFormat"`
+	SpecVersion     string                  `json:"specVersion"`
+	Version         int                     `json:"version"`
+	Vulnerabilities []cycloneDXVulnerability `json:"vulnerabilities"`
+}
+
+type cycloneDXVulnerability struct {
+	ID       string                `json:"id"`
+	Source   cycloneDXSource       `json:"source"`
+	Ratings  []cycloneDXRating     `json:"ratings,omitempty"`
+	Analysis cycloneDXVEXAnalysis  `json:"analysis"`
+	Affects  []cycloneDXAffects    `json:"affects"`
+}
+
+type cycloneDXSource struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXRating struct {
+	Score    float64 `json:"score,omitempty"`
+	Severity string  `json:"severity"`
+	Method   string  `json:"method"`
+	Vector   string  `json:"vector,omitempty"`
+}
+
+type cycloneDXVEXAnalysis struct {
+	State string `json:"state"`
+}
+
+type cycloneDXAffects struct {
+	Ref string `json:"ref"`
+}
+
+// vexAnalysisState maps a Wiz vulnerability's Status to a VEX
+// analysis.state.
+func vexAnalysisState(status string) string {
+	switch status {
+	case "OPEN":
+		return "exploitable"
+	case "IGNORED", "REJECTED":
+		return "not_affected"
+	case "RESOLVED":
+		return "fixed"
+	default:
+		return "in_triage"
+	}
+}
+
+// toCycloneDXVEX builds a VEX document from every CVE-bearing finding in
+// result.NormalizedFindings. Findings with no CVE (every GCP
+// misconfiguration, and any Wiz vulnerability that isn't CVE-backed)
+// aren't vulnerabilities in the SBOM sense and are omitted.
+func toCycloneDXVEX(result *ScanResult) cycloneDXVEXDocument {
+	doc := cycloneDXVEXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+	}
+
+	for _, f := range result.NormalizedFindings {
+		if f.CVE == "" {
+			continue
+		}
+
+		status := ""
+		if len(f.VendorTags) > 0 {
+			status = f.VendorTags[0]
+		}
+
+		vuln := cycloneDXVulnerability{
+			ID:     f.CVE,
+			Source: cycloneDXSource{Name: "Wiz"},
+			Analysis: cycloneDXVEXAnalysis{
+				State: vexAnalysisState(status),
+			},
+			Affects: []cycloneDXAffects{{Ref: f.Resource}},
+		}
+		if f.CVSSVector != "" {
+			vuln.Ratings = []cycloneDXRating{{
+				Score:    f.BaseScore,
+				Severity: f.Severity,
+				Method:   "CVSSv31",
+				Vector:   f.CVSSVector,
+			}}
+		}
+
+		doc.Vulnerabilities = append(doc.Vulnerabilities, vuln)
+	}
+
+	return doc
+}
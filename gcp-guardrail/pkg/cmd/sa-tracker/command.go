@@ -2,12 +2,14 @@ package sa_tracker
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam/baseline"
 	"github.com/hieptle/gcp-guardrail/pkg/gcp/sa"
+	"github.com/hieptle/gcp-guardrail/pkg/log"
+	sharedreport "github.com/hieptle/gcp-guardrail/pkg/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,7 +20,13 @@ var (
 	reportFormat string
 	outputPath   string
 	daysBack     int
-	verbose      bool
+	logLevel     string
+	failOn       string
+
+	baselineDB        string
+	baselineGCSBucket string
+	baselineGCSPrefix string
+	saveBaseline      bool
 )
 
 // GetCommand returns the sa-tracker command
@@ -31,60 +39,95 @@ accounts, over-permissioned accounts, and anomalous behavior.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create context
 			ctx := context.Background()
+			logger := log.New(os.Stderr, logLevel)
 
 			// Create a service account tracker
 			tracker, err := sa.NewTracker(ctx, projectID)
 			if err != nil {
-				fmt.Printf("Failed to create service account tracker: %v\n", err)
+				logger.Error("failed to create service account tracker", "error", err)
 				os.Exit(1)
 			}
 			defer tracker.Close()
 
 			// Convert daysBack to a duration for lookback period
 			lookbackPeriod := time.Duration(daysBack) * 24 * time.Hour
-			
-			if verbose {
-				fmt.Printf("Analyzing service account usage for project %s\n", projectID)
-				fmt.Printf("Looking back %d days of activity (%s)\n", daysBack, lookbackPeriod)
-			}
+
+			logger.Info("analyzing service account usage", "project", projectID, "days", daysBack)
 
 			// Run the service account analysis
 			serviceAccounts, err := tracker.AnalyzeUsage(lookbackPeriod)
 			if err != nil {
-				fmt.Printf("Failed to analyze service account usage: %v\n", err)
+				logger.Error("failed to analyze service account usage", "error", err)
 				os.Exit(1)
 			}
 
 			// Create a report
 			report := sa.NewReport(projectID, lookbackPeriod, serviceAccounts)
-			
-			// Determine report format
-			var format sa.ReportFormat
-			switch reportFormat {
-			case "json":
-				format = sa.JSONFormat
-			case "csv":
-				format = sa.CSVFormat
-			default:
-				format = sa.TextFormat
-			}
-			
-			// Output the report
-			if err := sa.WriteReportToFile(outputPath, report, format); err != nil {
-				fmt.Printf("Failed to write report: %v\n", err)
-				os.Exit(1)
+
+			if saveBaseline {
+				store, closeStore, err := openBaselineStore(ctx)
+				if err != nil {
+					logger.Warn("failed to open baseline store, skipping snapshot", "error", err)
+				} else {
+					if err := baseline.SaveSAReport(store, report.GeneratedAt, report); err != nil {
+						logger.Warn("failed to save baseline snapshot", "error", err)
+					}
+					closeStore()
+				}
 			}
 
-			// Print unused accounts count if verbose
-			if verbose {
-				fmt.Printf("Found %d total service accounts\n", report.Stats.TotalAccounts)
-				fmt.Printf("Found %d unused service accounts (%s%%)\n", 
-					report.Stats.UnusedAccounts, 
-					strconv.FormatFloat(float64(report.Stats.UnusedAccounts)/float64(report.Stats.TotalAccounts)*100, 'f', 1, 64))
-				fmt.Printf("Found %d over-privileged service accounts\n", report.Stats.OverPrivAccounts)
-				
+			// Output the report. "github" has no sa.ReportFormat of its
+			// own (it's rendered from the shared Finding conversion, not
+			// sa's own writers), so it's handled separately.
+			if reportFormat == "github" {
+				w := os.Stdout
 				if outputPath != "" {
-					fmt.Printf("Report written to %s in %s format\n", outputPath, reportFormat)
+					f, err := os.Create(outputPath)
+					if err != nil {
+						logger.Error("failed to create output file", "error", err)
+						os.Exit(1)
+					}
+					defer f.Close()
+					w = f
+				}
+				if err := sharedreport.Write(w, "gcpgolang-sa-tracker", sa.ToFindings(report), sharedreport.GitHubFormat); err != nil {
+					logger.Error("failed to write report", "error", err)
+					os.Exit(1)
+				}
+			} else {
+				var format sa.ReportFormat
+				switch reportFormat {
+				case "json":
+					format = sa.JSONFormat
+				case "csv":
+					format = sa.CSVFormat
+				case "sarif":
+					format = sa.SARIFFormat
+				case "junit":
+					format = sa.JUnitFormat
+				default:
+					format = sa.TextFormat
+				}
+
+				if err := sa.WriteReportToFile(outputPath, report, format); err != nil {
+					logger.Error("failed to write report", "error", err)
+					os.Exit(1)
+				}
+			}
+
+			logger.Info("analysis completed",
+				"total_accounts", report.Stats.TotalAccounts,
+				"unused_accounts", report.Stats.UnusedAccounts,
+				"over_privileged_accounts", report.Stats.OverPrivAccounts,
+				"output", outputPath,
+			)
+
+			// Fail the command with a non-zero exit code if any finding
+			// meets or exceeds the --fail-on severity, so this can gate CI.
+			if failOn != "" && failOn != "none" {
+				if report.Stats.OverPrivAccounts > 0 || (strings.EqualFold(failOn, "low") && report.Stats.UnusedAccounts > 0) {
+					logger.Error("failing: found service account findings at or above fail-on severity", "fail_on", strings.ToUpper(failOn))
+					os.Exit(1)
 				}
 			}
 		},
@@ -92,10 +135,15 @@ accounts, over-permissioned accounts, and anomalous behavior.`,
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.sa-tracker.yaml)")
 	rootCmd.PersistentFlags().StringVar(&projectID, "project", "", "GCP project ID")
-	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv)")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv, sarif, junit, github)")
 	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Output file path (default is stdout)")
 	rootCmd.PersistentFlags().IntVar(&daysBack, "days", 30, "Number of days to look back for service account activity")
-	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "none", "Exit non-zero if unused or over-privileged accounts are found at or above this severity (high, low, none)")
+	rootCmd.PersistentFlags().StringVar(&baselineDB, "baseline-db", "", "SQLite baseline database path (default: $HOME/.gcp-guardrail/baselines/sa.db)")
+	rootCmd.PersistentFlags().StringVar(&baselineGCSBucket, "baseline-gcs-bucket", "", "Store baseline snapshots in this GCS bucket instead of a local SQLite database")
+	rootCmd.PersistentFlags().StringVar(&baselineGCSPrefix, "baseline-gcs-prefix", "", "Object prefix to use within --baseline-gcs-bucket")
+	rootCmd.PersistentFlags().BoolVar(&saveBaseline, "save-baseline", true, "Record this run's report as a baseline snapshot for future drift comparisons")
 
 	rootCmd.MarkPersistentFlagRequired("project")
 
@@ -103,7 +151,41 @@ accounts, over-permissioned accounts, and anomalous behavior.`,
 	viper.BindPFlag("report-format", rootCmd.PersistentFlags().Lookup("report-format"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 	viper.BindPFlag("days", rootCmd.PersistentFlags().Lookup("days"))
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	
+	viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
+	viper.BindPFlag("fail-on", rootCmd.PersistentFlags().Lookup("fail-on"))
+	viper.BindPFlag("baseline-db", rootCmd.PersistentFlags().Lookup("baseline-db"))
+	viper.BindPFlag("baseline-gcs-bucket", rootCmd.PersistentFlags().Lookup("baseline-gcs-bucket"))
+	viper.BindPFlag("baseline-gcs-prefix", rootCmd.PersistentFlags().Lookup("baseline-gcs-prefix"))
+	viper.BindPFlag("save-baseline", rootCmd.PersistentFlags().Lookup("save-baseline"))
+
 	return rootCmd
+}
+
+// openBaselineStore opens the baseline.Store selected by --baseline-gcs-bucket
+// (if set) or --baseline-db (falling back to its default path). The
+// returned closer must be deferred by the caller; it's a no-op for the
+// GCS-backed store.
+func openBaselineStore(ctx context.Context) (baseline.Store, func() error, error) {
+	if baselineGCSBucket != "" {
+		store, err := baseline.NewGCSStore(ctx, baselineGCSBucket, baselineGCSPrefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, func() error { return nil }, nil
+	}
+
+	path := baselineDB
+	if path == "" {
+		var err error
+		path, err = baseline.DefaultPath("sa")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	store, err := baseline.NewSQLiteStore(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, store.Close, nil
 } 
\ No newline at end of file
@@ -2,45 +2,52 @@ package tf_validator
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
-	"time"
 	"strings"
+	"time"
 
+	analyzerplugin "github.com/hieptle/gcp-guardrail/pkg/analyzer"
+	sharedreport "github.com/hieptle/gcp-guardrail/pkg/report"
+	"github.com/hieptle/gcp-guardrail/pkg/terraform"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile       string
-	planFile      string
-	policyDir     string
-	outputFile    string
-	severity      string
-	failThreshold string
-	verbose       bool
-)
+	cfgFile          string
+	planFile         string
+	policyDir        string
+	outputFile       string
+	reportFormat     string
+	riskLevel        string
+	failOn           string
+	verbose          bool
+	enforcementScope string
+	tfDir            string
 
-// Violation represents a policy violation
-type Violation struct {
-	Severity    string `json:"severity"`
-	PolicyName  string `json:"policy_name"`
-	ResourceType string `json:"resource_type"`
-	ResourceName string `json:"resource_name"`
-	Message     string `json:"message"`
-	Remediation string `json:"remediation"`
-}
+	accessPolicyDir string
+	actor           string
+	environment     string
 
-// ValidationResult represents the output of the validation
-type ValidationResult struct {
-	PlanFile      string      `json:"plan_file"`
-	PolicyDir     string      `json:"policy_dir"`
-	SeverityLevel string      `json:"severity_level"`
-	Violations    []Violation `json:"violations"`
-	Timestamp     string      `json:"timestamp"`
-	Duration      string      `json:"duration"`
+	enableAnalyzerPlugins bool
+)
+
+// severityRank maps a severity name to an integer so --risk-level and
+// --fail-on can compare against it.
+func severityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return 5
+	case "HIGH":
+		return 4
+	case "MEDIUM":
+		return 3
+	case "LOW":
+		return 2
+	default:
+		return 1
+	}
 }
 
 // GetCommand returns the tf-validator command
@@ -51,172 +58,233 @@ func GetCommand() *cobra.Command {
 		Long: `Validates Terraform plans for GCP against security policies defined in Rego.
 Checks for configuration issues, security risks, and policy violations before applying.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Create context
 			ctx := context.Background()
-
-			// For now, we'll just print some information
-			// In a real implementation, we would validate the Terraform plan
-			
-			// Use time package to demonstrate it's being used
 			startTime := time.Now()
-			fmt.Printf("Starting validation at: %s\n", startTime.Format(time.RFC3339))
-			
-			fmt.Printf("Validating Terraform plan: %s\n", planFile)
-			fmt.Printf("Using policy directory: %s\n", policyDir)
-			fmt.Printf("Minimum severity level: %s\n", severity)
-			
-			// Parse severity level
-			sevLevel, err := strconv.Atoi(severity)
+
+			if verbose {
+				fmt.Printf("Starting validation at: %s\n", startTime.Format(time.RFC3339))
+				fmt.Printf("Validating Terraform plan: %s\n", planFile)
+				fmt.Printf("Using policy directory: %s\n", policyDir)
+			}
+
+			plan, err := terraform.NewParser(planFile).Parse()
 			if err != nil {
-				sevLevel = 0 // Default if not a number
+				fmt.Printf("Failed to parse Terraform plan: %v\n", err)
+				os.Exit(1)
 			}
-			
-			// Parse fail threshold level
-			failLevel, err := strconv.Atoi(failThreshold)
+
+			validator, err := terraform.NewValidator(ctx, policyDir)
 			if err != nil {
-				failLevel = 4 // Default to 4 (high severity) if not a number
+				fmt.Printf("Failed to load policies: %v\n", err)
+				os.Exit(1)
 			}
-			
-			// Sample violations (in a real implementation, these would come from policy evaluation)
-			// Severity mapping: 1=Low, 2=Medium, 3=High, 4=Critical, 5=Blocker
-			var violations []Violation
-			var highSevViolations int
-			
-			// Use context to demonstrate it's being used
-			select {
-			case <-ctx.Done():
-				fmt.Println("Validation was cancelled")
-			default:
-				// Simulate validation by creating sample violations
-				simulatedViolations := []Violation{
-					{
-						Severity:     "Medium",
-						PolicyName:   "storage_encryption",
-						ResourceType: "google_storage_bucket",
-						ResourceName: "my-non-compliant-bucket",
-						Message:      "Storage bucket is not encrypted",
-						Remediation:  "Add encryption { default_kms_key_name = ... } to the bucket configuration",
-					},
-					{
-						Severity:     "High",
-						PolicyName:   "public_bucket_access",
-						ResourceType: "google_storage_bucket",
-						ResourceName: "my-non-compliant-bucket",
-						Message:      "Storage bucket does not have uniform bucket-level access enabled",
-						Remediation:  "Set uniform_bucket_level_access = true in the bucket configuration",
-					},
-					{
-						Severity:     "Low",
-						PolicyName:   "versioning_recommended",
-						ResourceType: "google_storage_bucket",
-						ResourceName: "my-non-compliant-bucket",
-						Message:      "Storage bucket does not have versioning enabled",
-						Remediation:  "Add versioning { enabled = true } to the bucket configuration",
-					},
+
+			if tfDir != "" {
+				if err := validator.SetSourceDir(tfDir); err != nil {
+					fmt.Printf("Warning: failed to locate resources in %s, violations will have no source location: %v\n", tfDir, err)
 				}
-				
-				// Filter violations based on severity level
-				for _, v := range simulatedViolations {
-					var violationLevel int
-					switch strings.ToLower(v.Severity) {
-					case "low":
-						violationLevel = 1
-					case "medium":
-						violationLevel = 2
-					case "high":
-						violationLevel = 3
-					case "critical":
-						violationLevel = 4
-					case "blocker":
-						violationLevel = 5
-					default:
-						violationLevel = 1
-					}
-					
-					if violationLevel >= sevLevel {
-						violations = append(violations, v)
-						if violationLevel >= failLevel {
-							highSevViolations++
-						}
-					}
+			}
+
+			report, err := validator.Validate(planFile, plan, enforcementScope)
+			if err != nil {
+				fmt.Printf("Failed to validate plan: %v\n", err)
+				os.Exit(1)
+			}
+
+			// Filter violations based on --risk-level
+			threshold := severityRank(riskLevel)
+			var filtered []terraform.Violation
+			for _, v := range report.Violations {
+				if severityRank(v.Severity) >= threshold {
+					filtered = append(filtered, v)
 				}
-				
-				elapsedTime := time.Since(startTime)
-				
-				// Display detailed violation information
-				if len(violations) > 0 {
-					fmt.Printf("Found %d policy violations\n", len(violations))
-					fmt.Println("--------------------------------------------")
-					for i, v := range violations {
-						fmt.Printf("Violation #%d:\n", i+1)
-						fmt.Printf("  Severity:      %s\n", v.Severity)
-						fmt.Printf("  Policy:        %s\n", v.PolicyName)
-						fmt.Printf("  Resource Type: %s\n", v.ResourceType)
-						fmt.Printf("  Resource Name: %s\n", v.ResourceName)
-						fmt.Printf("  Issue:         %s\n", v.Message)
-						fmt.Printf("  Remediation:   %s\n", v.Remediation)
-						fmt.Println("--------------------------------------------")
-					}
+			}
+			if enableAnalyzerPlugins {
+				pluginViolations, err := runAnalyzerPlugins(ctx, plan)
+				if err != nil {
+					fmt.Printf("Warning: failed to run analyzer plugins: %v\n", err)
 				} else {
-					fmt.Println("No policy violations found")
-				}
-				
-				fmt.Printf("Validation completed in %s\n", elapsedTime)
-				
-				// Create and save validation results
-				result := ValidationResult{
-					PlanFile:      planFile,
-					PolicyDir:     policyDir,
-					SeverityLevel: severity,
-					Violations:    violations,
-					Timestamp:     startTime.Format(time.RFC3339),
-					Duration:      elapsedTime.String(),
+					filtered = append(filtered, pluginViolations...)
 				}
-				
-				// Save to output file if specified
+			}
+
+			report = terraform.NewReport(report.PlanFile, filtered)
+
+			if reportFormat == "sarif" || reportFormat == "github" {
+				// Routed through pkg/report instead of terraform's own
+				// writeSARIFReport so a SARIF result gets a real
+				// physicalLocation when --tf-dir resolved one, and so
+				// "github" (Actions workflow commands) is available at all.
+				w := os.Stdout
 				if outputFile != "" {
-					resultJSON, err := json.MarshalIndent(result, "", "  ")
+					f, err := os.Create(outputFile)
 					if err != nil {
-						fmt.Printf("Error creating JSON output: %v\n", err)
-					} else {
-						err = os.WriteFile(outputFile, resultJSON, 0644)
-						if err != nil {
-							fmt.Printf("Error writing output file: %v\n", err)
-						} else {
-							fmt.Printf("Results written to %s\n", outputFile)
-						}
+						fmt.Printf("Failed to create file: %v\n", err)
+						os.Exit(1)
 					}
+					defer f.Close()
+					w = f
+				}
+				sharedFormat := sharedreport.SARIFFormat
+				if reportFormat == "github" {
+					sharedFormat = sharedreport.GitHubFormat
 				}
-				
-				// Only fail if high severity issues are found (based on threshold)
-				if highSevViolations > 0 {
-					fmt.Printf("Terraform plan validation failed due to %d high severity violations!\n", highSevViolations)
+				if err := sharedreport.Write(w, "gcpgolang-tf-validator", terraform.ToFindings(report), sharedFormat); err != nil {
+					fmt.Printf("Failed to write report: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				var format terraform.ReportFormat
+				switch reportFormat {
+				case "json":
+					format = terraform.JSONFormat
+				case "csv":
+					format = terraform.CSVFormat
+				default:
+					format = terraform.TextFormat
+				}
+
+				if err := terraform.WriteReportToFile(outputFile, report, format); err != nil {
+					fmt.Printf("Failed to write report: %v\n", err)
 					os.Exit(1)
-				} else if len(violations) > 0 {
-					fmt.Println("Terraform plan validation completed with warnings.")
-				} else {
-					fmt.Println("Terraform plan validation succeeded!")
 				}
 			}
+
+			if verbose {
+				fmt.Printf("Validation completed in %s\n", time.Since(startTime))
+			}
+
+			// Fail the command with a non-zero exit code if any *blocking*
+			// (Deny) violation meets or exceeds the --fail-on severity, so
+			// this can gate CI. Violations whose enforcement scope mapped
+			// them to "warn"/"dryrun" for this --enforcement-scope are
+			// always reported but never fail the build.
+			if failOn != "" && failOn != "none" {
+				failThreshold := severityRank(failOn)
+				for _, v := range report.Violations {
+					if v.Deny && severityRank(v.Severity) >= failThreshold {
+						fmt.Printf("Terraform plan validation failed: found violations at or above fail-on severity (%s) in enforcement scope %q\n", strings.ToUpper(failOn), enforcementScope)
+						os.Exit(1)
+					}
+				}
+			}
+
+			// If an access policy directory was given, chain a second
+			// plan->access evaluation that gates the apply on actor/
+			// environment RBAC-like rules, on top of configuration
+			// compliance.
+			if accessPolicyDir != "" {
+				accessEvaluator, err := terraform.NewAccessEvaluator(ctx, accessPolicyDir)
+				if err != nil {
+					fmt.Printf("Failed to load access policies: %v\n", err)
+					os.Exit(1)
+				}
+
+				decision, err := accessEvaluator.Evaluate(report, actor, environment)
+				if err != nil {
+					fmt.Printf("Failed to evaluate access policy: %v\n", err)
+					os.Exit(1)
+				}
+
+				if verbose {
+					fmt.Printf("Access policy risk score: %d\n", decision.RiskScore)
+				}
+
+				if !decision.Allowed {
+					fmt.Printf("Terraform plan validation failed: access policy denied %q in %q environment\n", actor, environment)
+					for _, v := range decision.Violations {
+						fmt.Printf("  - [%s] %s\n", v.Severity, v.Message)
+					}
+					os.Exit(1)
+				}
+			}
+
+			fmt.Printf("Terraform plan validation completed: %d violation(s) found\n", len(report.Violations))
 		},
 	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.tf-validator.yaml)")
-	rootCmd.PersistentFlags().StringVar(&planFile, "plan", "", "Terraform plan file (JSON format)")
-	rootCmd.PersistentFlags().StringVar(&policyDir, "policy-dir", "policies/terraform", "Directory containing Rego policies")
-	rootCmd.PersistentFlags().StringVar(&outputFile, "output", "", "Output file for validation results (default is stdout)")
-	rootCmd.PersistentFlags().StringVar(&severity, "severity", "2", "Minimum severity level (1-5)")
-	rootCmd.PersistentFlags().StringVar(&failThreshold, "fail-threshold", "4", "Minimum severity level that causes validation to fail (1-5, default 4)")
+	rootCmd.PersistentFlags().StringVar(&planFile, "plan", "", "Terraform plan file (JSON format, from `terraform show -json`)")
+	rootCmd.PersistentFlags().StringVar(&policyDir, "policy-dir", "policies/terraform", "Directory containing Rego policies (package terraform.gcp.<resource_type>)")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output", "", "Output file path (default is stdout)")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "text", "Output format (text, json, csv, sarif, github)")
+	rootCmd.PersistentFlags().StringVar(&tfDir, "tf-dir", "", "Directory containing the .tf source the plan was generated from; when set, sarif/github output includes the resource's file and line (default: violations carry no source location)")
+	rootCmd.PersistentFlags().StringVar(&riskLevel, "risk-level", "low", "Minimum severity to report (critical, high, medium, low)")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "high", "Exit non-zero if any violation at or above this severity is found (critical, high, medium, low, none)")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&enforcementScope, "enforcement-scope", "plan", "Which enforcement scope is running (plan, apply); policies scoped to the other are skipped, and --fail-on only blocks on violations whose enforcement actions include \"deny\"")
+	rootCmd.PersistentFlags().StringVar(&accessPolicyDir, "access-policy-dir", "", "Directory containing \"plan\"/\"access\" package Rego policies that gate the apply on actor/environment RBAC-like rules, chained after configuration compliance (default: access control is skipped)")
+	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Identity applying this plan, passed to the access policy as input.actor")
+	rootCmd.PersistentFlags().StringVar(&environment, "environment", "", "Environment this plan is being applied to, passed to the access policy as input.environment")
+	rootCmd.PersistentFlags().BoolVar(&enableAnalyzerPlugins, "enable-analyzer-plugins", false, "Discover and run analyzer plugins (binaries named gcpgolang-analyzer-* on $PATH) and merge their diagnostics in as additional violations")
 
 	rootCmd.MarkPersistentFlagRequired("plan")
 
 	viper.BindPFlag("plan", rootCmd.PersistentFlags().Lookup("plan"))
 	viper.BindPFlag("policy-dir", rootCmd.PersistentFlags().Lookup("policy-dir"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
-	viper.BindPFlag("severity", rootCmd.PersistentFlags().Lookup("severity"))
-	viper.BindPFlag("fail-threshold", rootCmd.PersistentFlags().Lookup("fail-threshold"))
+	viper.BindPFlag("report-format", rootCmd.PersistentFlags().Lookup("report-format"))
+	viper.BindPFlag("tf-dir", rootCmd.PersistentFlags().Lookup("tf-dir"))
+	viper.BindPFlag("risk-level", rootCmd.PersistentFlags().Lookup("risk-level"))
+	viper.BindPFlag("fail-on", rootCmd.PersistentFlags().Lookup("fail-on"))
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	
+	viper.BindPFlag("enforcement-scope", rootCmd.PersistentFlags().Lookup("enforcement-scope"))
+	viper.BindPFlag("access-policy-dir", rootCmd.PersistentFlags().Lookup("access-policy-dir"))
+	viper.BindPFlag("actor", rootCmd.PersistentFlags().Lookup("actor"))
+	viper.BindPFlag("environment", rootCmd.PersistentFlags().Lookup("environment"))
+	viper.BindPFlag("enable-analyzer-plugins", rootCmd.PersistentFlags().Lookup("enable-analyzer-plugins"))
+
 	return rootCmd
-} 
\ No newline at end of file
+}
+
+// runAnalyzerPlugins discovers analyzer plugin binaries on $PATH, runs
+// AnalyzeStack against the planned resources, and converts each returned
+// diagnostic into a terraform.Violation so plugin and in-process Rego
+// results share one report.
+func runAnalyzerPlugins(ctx context.Context, plan *terraform.Plan) ([]terraform.Violation, error) {
+	binaries, err := analyzerplugin.Discover()
+	if err != nil {
+		return nil, fmt.Errorf("discover analyzer plugins: %w", err)
+	}
+	if len(binaries) == 0 {
+		return nil, nil
+	}
+
+	resources := make([]analyzerplugin.ResourceProperties, 0, len(plan.ResourceChanges))
+	for _, change := range plan.ResourceChanges {
+		resources = append(resources, analyzerplugin.ResourceProperties{
+			URN:        change.Resource.Name,
+			Type:       change.Resource.Type,
+			Properties: change.Resource.Attributes,
+		})
+	}
+
+	var violations []terraform.Violation
+	for _, binaryPath := range binaries {
+		client, err := analyzerplugin.Launch(binaryPath)
+		if err != nil {
+			fmt.Printf("Failed to launch analyzer plugin %s: %v\n", binaryPath, err)
+			continue
+		}
+
+		diagnostics, err := client.AnalyzeStack(ctx, resources)
+		client.Close()
+		if err != nil {
+			fmt.Printf("Analyzer plugin %s failed: %v\n", client.Name, err)
+			continue
+		}
+
+		for _, d := range diagnostics {
+			violations = append(violations, terraform.Violation{
+				Severity:     strings.ToUpper(d.Severity),
+				ResourceType: d.URN,
+				ResourceName: d.URN,
+				Message:      d.Message,
+				Policy:       d.PolicyName,
+				Deny:         true,
+			})
+		}
+	}
+
+	return violations, nil
+}
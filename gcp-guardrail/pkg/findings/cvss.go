@@ -0,0 +1,137 @@
+package findings
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// cvssMetrics holds the raw value letter for each CVSS v3 base metric,
+// keyed by the vector's metric abbreviation (AV, AC, PR, UI, S, C, I,
+// A); cvssBaseScore looks the numeric weights up from these letters.
+type cvssMetrics map[string]string
+
+var cvssAVWeights = map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}
+var cvssACWeights = map[string]float64{"L": 0.77, "H": 0.44}
+var cvssPRWeightsUnchanged = map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}
+var cvssPRWeightsChanged = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+var cvssUIWeights = map[string]float64{"N": 0.85, "R": 0.62}
+var cvssCIAWeights = map[string]float64{"H": 0.56, "L": 0.22, "N": 0}
+
+// requiredCVSSMetrics are the eight base metrics every v3/v3.1 vector
+// must declare.
+var requiredCVSSMetrics = []string{"AV", "AC", "PR", "UI", "S", "C", "I", "A"}
+
+// parseCVSSVector parses a CVSS v3.0/v3.1 vector string (e.g.
+// "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H") into its base metrics,
+// rejecting vectors missing any of the eight required metrics.
+func parseCVSSVector(vector string) (cvssMetrics, error) {
+	metrics := cvssMetrics{}
+	for _, part := range strings.Split(vector, "/") {
+		if strings.HasPrefix(part, "CVSS:") {
+			continue
+		}
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid CVSS vector component %q", part)
+		}
+		metrics[kv[0]] = kv[1]
+	}
+
+	for _, m := range requiredCVSSMetrics {
+		if _, ok := metrics[m]; !ok {
+			return nil, fmt.Errorf("CVSS vector %q is missing required metric %s", vector, m)
+		}
+	}
+
+	return metrics, nil
+}
+
+// cvssBaseScore computes the CVSS v3.1 base score from a parsed vector,
+// following the official formula from the CVSS v3.1 specification
+// document (section 7.1, "CVSS v3.1 Equations").
+func cvssBaseScore(metrics cvssMetrics) (float64, error) {
+	changed := metrics["S"] == "C"
+
+	c, ok := cvssCIAWeights[metrics["C"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid confidentiality impact %q", metrics["C"])
+	}
+	i, ok := cvssCIAWeights[metrics["I"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid integrity impact %q", metrics["I"])
+	}
+	a, ok := cvssCIAWeights[metrics["A"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid availability impact %q", metrics["A"])
+	}
+
+	iscBase := 1 - ((1 - c) * (1 - i) * (1 - a))
+	var impact float64
+	if changed {
+		impact = 7.52*(iscBase-0.029) - 3.25*math.Pow(iscBase-0.02, 15)
+	} else {
+		impact = 6.42 * iscBase
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+
+	av, ok := cvssAVWeights[metrics["AV"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid attack vector %q", metrics["AV"])
+	}
+	ac, ok := cvssACWeights[metrics["AC"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid attack complexity %q", metrics["AC"])
+	}
+	prWeights := cvssPRWeightsUnchanged
+	if changed {
+		prWeights = cvssPRWeightsChanged
+	}
+	pr, ok := prWeights[metrics["PR"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid privileges required %q", metrics["PR"])
+	}
+	ui, ok := cvssUIWeights[metrics["UI"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid user interaction %q", metrics["UI"])
+	}
+
+	exploitability := 8.22 * av * ac * pr * ui
+
+	var score float64
+	if changed {
+		score = roundUpToNearestTenth(math.Min(1.08*(impact+exploitability), 10))
+	} else {
+		score = roundUpToNearestTenth(math.Min(impact+exploitability, 10))
+	}
+	return score, nil
+}
+
+// roundUpToNearestTenth implements the CVSS spec's Roundup function:
+// round up to the nearest value with one decimal place.
+func roundUpToNearestTenth(v float64) float64 {
+	intInput := math.Round(v * 100000)
+	if math.Mod(intInput, 10000) == 0 {
+		return intInput / 100000
+	}
+	return (math.Floor(intInput/10000) + 1) / 10
+}
+
+// severityFromScore maps a CVSS base score to the CRITICAL/HIGH/MEDIUM/LOW/NONE
+// qualitative rating defined in the CVSS v3.1 specification.
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9.0:
+		return "CRITICAL"
+	case score >= 7.0:
+		return "HIGH"
+	case score >= 4.0:
+		return "MEDIUM"
+	case score > 0.0:
+		return "LOW"
+	default:
+		return "NONE"
+	}
+}
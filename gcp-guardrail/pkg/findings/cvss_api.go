@@ -0,0 +1,20 @@
+package findings
+
+import "fmt"
+
+// ParseCVSS parses a CVSS v3.0/v3.1 vector string and returns its base
+// score and the qualitative severity derived from that score (CRITICAL,
+// HIGH, MEDIUM, LOW, or NONE), per the CVSS v3.1 specification.
+func ParseCVSS(vector string) (score float64, severity string, err error) {
+	metrics, err := parseCVSSVector(vector)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse CVSS vector: %w", err)
+	}
+
+	score, err = cvssBaseScore(metrics)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to score CVSS vector: %w", err)
+	}
+
+	return score, severityFromScore(score), nil
+}
@@ -0,0 +1,45 @@
+// Package findings normalizes misconfig-scanner's two native result
+// types (GCP Misconfiguration and Wiz WizVulnerability) into one Finding
+// type with a CVSS-aware severity, so downstream consumers (severity
+// counts, ignore rules, report formats) don't need to special-case the
+// source.
+package findings
+
+import "time"
+
+// Finding is the common shape every misconfig-scanner result is
+// normalized into.
+type Finding struct {
+	// Source identifies where the finding came from: "gcp" for a scanned
+	// Misconfiguration, "wiz" for a WizVulnerability.
+	Source         string    `json:"source"`
+	RuleID         string    `json:"rule_id"`
+	Resource       string    `json:"resource"`
+	ResourceID     string    `json:"resource_id"`
+	Message        string    `json:"message"`
+	Remediation    string    `json:"remediation"`
+	Category       string    `json:"category"`
+	Timestamp      time.Time `json:"timestamp"`
+
+	// CVE is set when the finding is tied to a specific CVE (Wiz
+	// vulnerabilities only; GCP misconfigurations never carry one).
+	CVE string `json:"cve,omitempty"`
+	// CVSSVector is the raw CVSS v3 vector string the scores below were
+	// parsed from, e.g. "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H".
+	CVSSVector string `json:"cvss_vector,omitempty"`
+	BaseScore           float64 `json:"base_score,omitempty"`
+	TemporalScore       float64 `json:"temporal_score,omitempty"`
+	EnvironmentalScore  float64 `json:"environmental_score,omitempty"`
+
+	// FixState mirrors Grype's match.Fix.State: "fixed", "not-fixed",
+	// "wont-fix", or "unknown" when the source gives no fix information.
+	FixState string `json:"fix_state"`
+	// VendorTags carries source-specific labels ignore rules can match on
+	// (e.g. a Wiz vulnerability's "Status").
+	VendorTags []string `json:"vendor_tags,omitempty"`
+
+	// Severity is derived from CVSSVector's score when present, and falls
+	// back to the source's own severity otherwise, so counts stay
+	// consistent regardless of where a finding came from.
+	Severity string `json:"severity"`
+}
@@ -0,0 +1,180 @@
+package findings
+
+import "testing"
+
+func TestParseCVSS(t *testing.T) {
+	tests := []struct {
+		name         string
+		vector       string
+		wantScore    float64
+		wantSeverity string
+		wantErr      bool
+	}{
+		{
+			name:         "unchanged scope, all high",
+			vector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			wantScore:    9.8,
+			wantSeverity: "CRITICAL",
+		},
+		{
+			name:         "changed scope, all high caps at 10",
+			vector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H",
+			wantScore:    10,
+			wantSeverity: "CRITICAL",
+		},
+		{
+			name:         "low-severity vector",
+			vector:       "CVSS:3.1/AV:P/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N",
+			wantScore:    1.6,
+			wantSeverity: "LOW",
+		},
+		{
+			name:         "medium-severity vector",
+			vector:       "CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:U/C:L/I:L/A:N",
+			wantScore:    5.4,
+			wantSeverity: "MEDIUM",
+		},
+		{
+			name:         "no impact is NONE",
+			vector:       "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N",
+			wantScore:    0,
+			wantSeverity: "NONE",
+		},
+		{
+			name:    "missing required metric",
+			vector:  "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+			wantErr: true,
+		},
+		{
+			name:    "malformed component",
+			vector:  "CVSS:3.1/AV:N/garbage/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			wantErr: true,
+		},
+		{
+			name:    "invalid metric value",
+			vector:  "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, severity, err := ParseCVSS(tt.vector)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCVSS(%q) returned no error, want one", tt.vector)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCVSS(%q) returned error: %v", tt.vector, err)
+			}
+			if score != tt.wantScore {
+				t.Errorf("ParseCVSS(%q) score = %v, want %v", tt.vector, score, tt.wantScore)
+			}
+			if severity != tt.wantSeverity {
+				t.Errorf("ParseCVSS(%q) severity = %q, want %q", tt.vector, severity, tt.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestIgnoreRuleMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		rule IgnoreRule
+		f    Finding
+		want bool
+	}{
+		{
+			name: "matching CVE",
+			rule: IgnoreRule{CVE: "CVE-2024-1234"},
+			f:    Finding{CVE: "CVE-2024-1234"},
+			want: true,
+		},
+		{
+			name: "non-matching CVE",
+			rule: IgnoreRule{CVE: "CVE-2024-1234"},
+			f:    Finding{CVE: "CVE-2024-5678"},
+			want: false,
+		},
+		{
+			name: "matching resource glob",
+			rule: IgnoreRule{ResourceGlob: "test-*"},
+			f:    Finding{Resource: "test-bucket"},
+			want: true,
+		},
+		{
+			name: "non-matching resource glob",
+			rule: IgnoreRule{ResourceGlob: "test-*"},
+			f:    Finding{Resource: "prod-bucket"},
+			want: false,
+		},
+		{
+			name: "severity below threshold is suppressed",
+			rule: IgnoreRule{SeverityBelow: "MEDIUM"},
+			f:    Finding{Severity: "LOW"},
+			want: true,
+		},
+		{
+			name: "severity at threshold is not suppressed",
+			rule: IgnoreRule{SeverityBelow: "MEDIUM"},
+			f:    Finding{Severity: "MEDIUM"},
+			want: false,
+		},
+		{
+			name: "matching vendor tag",
+			rule: IgnoreRule{VendorTag: "false-positive"},
+			f:    Finding{VendorTags: []string{"reviewed", "false-positive"}},
+			want: true,
+		},
+		{
+			name: "missing vendor tag",
+			rule: IgnoreRule{VendorTag: "false-positive"},
+			f:    Finding{VendorTags: []string{"reviewed"}},
+			want: false,
+		},
+		{
+			name: "all set fields must match (AND semantics)",
+			rule: IgnoreRule{CVE: "CVE-2024-1234", FixState: "wont-fix"},
+			f:    Finding{CVE: "CVE-2024-1234", FixState: "not-fixed"},
+			want: false,
+		},
+		{
+			name: "empty rule matches everything",
+			rule: IgnoreRule{},
+			f:    Finding{CVE: "CVE-2024-1234"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.f); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	rules := []IgnoreRule{
+		{ResourceGlob: "test-*", Reason: "test resources are out of scope"},
+	}
+	findings := []Finding{
+		{Resource: "test-bucket", RuleID: "R1"},
+		{Resource: "prod-bucket", RuleID: "R2"},
+	}
+
+	kept, ignored := Apply(findings, rules)
+
+	if len(kept) != 1 || kept[0].RuleID != "R2" {
+		t.Errorf("kept = %+v, want only R2", kept)
+	}
+	if len(ignored) != 1 || ignored[0].Finding.RuleID != "R1" {
+		t.Errorf("ignored = %+v, want only R1", ignored)
+	}
+	if ignored[0].MatchedRule.Reason != "test resources are out of scope" {
+		t.Errorf("ignored[0].MatchedRule.Reason = %q, want the matching rule", ignored[0].MatchedRule.Reason)
+	}
+}
@@ -0,0 +1,118 @@
+package findings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoreRule suppresses any Finding it matches, borrowing the ignore-rule
+// pattern from Grype's match.IgnoreRule: every non-empty field set on a
+// rule must match for the rule to apply (AND semantics within a rule; a
+// Finding is suppressed if ANY rule matches it).
+type IgnoreRule struct {
+	// CVE, if set, must exactly match the Finding's CVE.
+	CVE string `yaml:"cve,omitempty"`
+	// ResourceGlob, if set, is a filepath.Match-style glob the Finding's
+	// Resource must match (e.g. "test-*" or "*-sandbox").
+	ResourceGlob string `yaml:"resource,omitempty"`
+	// FixState, if set, must exactly match the Finding's FixState
+	// ("fixed", "not-fixed", "wont-fix", "unknown").
+	FixState string `yaml:"fix-state,omitempty"`
+	// SeverityBelow, if set, suppresses any Finding whose severity ranks
+	// strictly below it (e.g. "MEDIUM" suppresses LOW and NONE findings).
+	SeverityBelow string `yaml:"severity-below,omitempty"`
+	// VendorTag, if set, must be present in the Finding's VendorTags.
+	VendorTag string `yaml:"vendor-tag,omitempty"`
+	// Reason documents why the rule exists; it isn't matched against,
+	// only carried through to the ignored_findings output.
+	Reason string `yaml:"reason,omitempty"`
+}
+
+// severityRank orders severities from least to most severe, for
+// SeverityBelow comparisons.
+var severityRank = map[string]int{
+	"NONE":     0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// Matches reports whether every field r sets matches f.
+func (r IgnoreRule) Matches(f Finding) bool {
+	if r.CVE != "" && r.CVE != f.CVE {
+		return false
+	}
+	if r.FixState != "" && r.FixState != f.FixState {
+		return false
+	}
+	if r.ResourceGlob != "" {
+		matched, err := filepath.Match(r.ResourceGlob, f.Resource)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	if r.SeverityBelow != "" {
+		threshold, ok := severityRank[r.SeverityBelow]
+		if !ok || severityRank[f.Severity] >= threshold {
+			return false
+		}
+	}
+	if r.VendorTag != "" {
+		found := false
+		for _, tag := range f.VendorTags {
+			if tag == r.VendorTag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IgnoredFinding records a suppressed Finding alongside the rule that
+// suppressed it, so --ignore-file suppressions stay auditable in JSON
+// output instead of silently disappearing.
+type IgnoredFinding struct {
+	Finding     Finding    `json:"finding"`
+	MatchedRule IgnoreRule `json:"matched_rule"`
+}
+
+// LoadIgnoreRules loads a YAML list of IgnoreRules from path.
+func LoadIgnoreRules(path string) ([]IgnoreRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file %s: %w", path, err)
+	}
+
+	var rules []IgnoreRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse ignore file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Apply splits all into the findings kept after filtering and the
+// findings suppressed by the first matching rule.
+func Apply(all []Finding, rules []IgnoreRule) (kept []Finding, ignored []IgnoredFinding) {
+	for _, f := range all {
+		suppressed := false
+		for _, rule := range rules {
+			if rule.Matches(f) {
+				ignored = append(ignored, IgnoredFinding{Finding: f, MatchedRule: rule})
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, f)
+		}
+	}
+	return kept, ignored
+}
@@ -0,0 +1,33 @@
+// Package asset scans GCP resources for security misconfigurations by
+// enumerating them through Cloud Asset Inventory and evaluating each one
+// against a pluggable set of PolicyMatchers, one per resource family
+// (storage, compute, network, iam, gke, kms).
+package asset
+
+// Asset is the common representation every PolicyMatcher evaluates,
+// flattened from a Cloud Asset Inventory SearchAllResources result so
+// matchers don't need to know which RPC or API version produced it.
+type Asset struct {
+	Name        string
+	AssetType   string
+	Project     string
+	Location    string
+	DisplayName string
+	Labels      map[string]string
+	// Attributes holds the resource's type-specific fields (e.g. a
+	// bucket's "iamConfiguration", a firewall's "sourceRanges"), taken
+	// from the CAI result's AdditionalAttributes struct.
+	Attributes map[string]interface{}
+}
+
+// Misconfiguration is a single policy violation found on an Asset by a
+// PolicyMatcher.
+type Misconfiguration struct {
+	ResourceType   string
+	ResourceName   string
+	ResourceID     string
+	Issue          string
+	Severity       string
+	Recommendation string
+	Category       string
+}
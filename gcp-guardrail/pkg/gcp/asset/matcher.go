@@ -0,0 +1,70 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PolicyMatcher evaluates a single Asset for misconfigurations within one
+// resource family. Following the pattern used by SCA/vuln scanners,
+// implementations register themselves via RegisterMatcher so the set of
+// checks run by the Scanner is driven by what's linked in, and new checks
+// are added by dropping in a matcher rather than editing the Scanner or
+// the CLI.
+type PolicyMatcher interface {
+	// Family is the --scan-type value that selects this matcher (e.g.
+	// "storage"). Each matcher sets its Misconfigurations' Category
+	// itself (e.g. "Storage").
+	Family() string
+	// AssetTypes lists the CAI asset types (e.g.
+	// "storage.googleapis.com/Bucket") this matcher inspects, used to
+	// scope the SearchAllResources query to only what's needed.
+	AssetTypes() []string
+	// Match evaluates a single asset and returns any misconfigurations found.
+	Match(ctx context.Context, asset Asset) ([]Misconfiguration, error)
+}
+
+var registry = map[string]PolicyMatcher{}
+
+// RegisterMatcher adds a PolicyMatcher to the default registry. Matchers
+// call this from an init() function so importing the asset package is
+// enough to enable them.
+func RegisterMatcher(m PolicyMatcher) {
+	if _, exists := registry[m.Family()]; exists {
+		panic(fmt.Sprintf("asset: matcher for family %q registered twice", m.Family()))
+	}
+	registry[m.Family()] = m
+}
+
+// MatcherFamilies returns the families of every registered matcher, sorted
+// for deterministic --scan-type validation and help output.
+func MatcherFamilies() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SelectMatchers resolves the matchers to run for a --scan-type value.
+// "all" (or empty) selects every registered matcher, sorted by Family for
+// deterministic output ordering.
+func SelectMatchers(scanType string) ([]PolicyMatcher, error) {
+	if scanType == "" || scanType == "all" {
+		matchers := make([]PolicyMatcher, 0, len(registry))
+		for _, m := range registry {
+			matchers = append(matchers, m)
+		}
+		sort.Slice(matchers, func(i, j int) bool { return matchers[i].Family() < matchers[j].Family() })
+		return matchers, nil
+	}
+
+	m, ok := registry[scanType]
+	if !ok {
+		return nil, fmt.Errorf("unknown scan type %q (available: all, %s)", scanType, strings.Join(MatcherFamilies(), ", "))
+	}
+	return []PolicyMatcher{m}, nil
+}
@@ -0,0 +1,203 @@
+package asset
+
+import "context"
+
+func init() {
+	RegisterMatcher(storageMatcher{})
+	RegisterMatcher(computeMatcher{})
+	RegisterMatcher(networkMatcher{})
+	RegisterMatcher(iamMatcher{})
+	RegisterMatcher(gkeMatcher{})
+	RegisterMatcher(kmsMatcher{})
+}
+
+// storageMatcher flags buckets that allow non-uniform (ACL-based) access
+// or have no default CMEK encryption configured.
+type storageMatcher struct{}
+
+func (storageMatcher) Family() string      { return "storage" }
+func (storageMatcher) AssetTypes() []string { return []string{"storage.googleapis.com/Bucket"} }
+
+func (storageMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	var findings []Misconfiguration
+
+	enabled := false
+	if iamConfig, ok := a.Attributes["iamConfiguration"].(map[string]interface{}); ok {
+		if ubla, ok := iamConfig["uniformBucketLevelAccess"].(map[string]interface{}); ok {
+			enabled, _ = ubla["enabled"].(bool)
+		}
+	}
+	if !enabled {
+		findings = append(findings, Misconfiguration{
+			ResourceType:   a.AssetType,
+			ResourceName:   a.DisplayName,
+			ResourceID:     a.Name,
+			Issue:          "Uniform bucket-level access is disabled",
+			Severity:       "MEDIUM",
+			Recommendation: "Enable uniform bucket-level access and remove object ACLs",
+			Category:       "Storage",
+		})
+	}
+
+	if encryption, ok := a.Attributes["encryption"].(map[string]interface{}); !ok || encryption["defaultKmsKeyName"] == nil {
+		findings = append(findings, Misconfiguration{
+			ResourceType:   a.AssetType,
+			ResourceName:   a.DisplayName,
+			ResourceID:     a.Name,
+			Issue:          "Bucket has no default CMEK encryption configured",
+			Severity:       "LOW",
+			Recommendation: "Set a default KMS key so objects are encrypted with a customer-managed key",
+			Category:       "Storage",
+		})
+	}
+
+	return findings, nil
+}
+
+// computeMatcher flags instances with an external IP address, which are
+// reachable from the internet unless firewall rules restrict access.
+type computeMatcher struct{}
+
+func (computeMatcher) Family() string      { return "compute" }
+func (computeMatcher) AssetTypes() []string { return []string{"compute.googleapis.com/Instance"} }
+
+func (computeMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	interfaces, _ := a.Attributes["networkInterfaces"].([]interface{})
+	for _, raw := range interfaces {
+		iface, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		accessConfigs, _ := iface["accessConfigs"].([]interface{})
+		if len(accessConfigs) > 0 {
+			return []Misconfiguration{{
+				ResourceType:   a.AssetType,
+				ResourceName:   a.DisplayName,
+				ResourceID:     a.Name,
+				Issue:          "Instance has an external IP address",
+				Severity:       "HIGH",
+				Recommendation: "Remove the external IP and use Identity-Aware Proxy for administrative access",
+				Category:       "Compute",
+			}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// networkMatcher flags firewall rules that allow ingress from 0.0.0.0/0.
+type networkMatcher struct{}
+
+func (networkMatcher) Family() string      { return "network" }
+func (networkMatcher) AssetTypes() []string { return []string{"compute.googleapis.com/Firewall"} }
+
+func (networkMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	if a.Attributes["direction"] != "INGRESS" {
+		return nil, nil
+	}
+
+	ranges, _ := a.Attributes["sourceRanges"].([]interface{})
+	for _, r := range ranges {
+		if r == "0.0.0.0/0" {
+			return []Misconfiguration{{
+				ResourceType:   a.AssetType,
+				ResourceName:   a.DisplayName,
+				ResourceID:     a.Name,
+				Issue:          "Firewall rule allows ingress from 0.0.0.0/0",
+				Severity:       "CRITICAL",
+				Recommendation: "Restrict sourceRanges to specific, known IP ranges",
+				Category:       "Network",
+			}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// iamMatcher flags projects with a binding that grants Owner or Editor,
+// reporting one finding per member of that binding, whatever the member
+// type (user, group, service account, ...). Bindings are attached to the Asset's
+// Attributes["bindings"] by the Scanner from a separate IAM policy
+// export, since SearchAllResources itself doesn't return IAM policies.
+type iamMatcher struct{}
+
+func (iamMatcher) Family() string { return "iam" }
+func (iamMatcher) AssetTypes() []string {
+	return []string{"cloudresourcemanager.googleapis.com/Project"}
+}
+
+func (iamMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	bindings, _ := a.Attributes["bindings"].([]interface{})
+	var findings []Misconfiguration
+	for _, raw := range bindings {
+		binding, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		role, _ := binding["role"].(string)
+		if role != "roles/owner" && role != "roles/editor" {
+			continue
+		}
+		members, _ := binding["members"].([]interface{})
+		for _, m := range members {
+			member, _ := m.(string)
+			if member == "" {
+				continue
+			}
+			findings = append(findings, Misconfiguration{
+				ResourceType:   a.AssetType,
+				ResourceName:   a.DisplayName,
+				ResourceID:     a.Name,
+				Issue:          member + " is granted " + role + " at the project level",
+				Severity:       "HIGH",
+				Recommendation: "Grant more specific predefined or custom roles instead of Owner/Editor",
+				Category:       "IAM",
+			})
+		}
+	}
+	return findings, nil
+}
+
+// gkeMatcher flags GKE clusters that don't run private nodes.
+type gkeMatcher struct{}
+
+func (gkeMatcher) Family() string      { return "gke" }
+func (gkeMatcher) AssetTypes() []string { return []string{"container.googleapis.com/Cluster"} }
+
+func (gkeMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	private := false
+	if cfg, ok := a.Attributes["privateClusterConfig"].(map[string]interface{}); ok {
+		private, _ = cfg["enablePrivateNodes"].(bool)
+	}
+	if private {
+		return nil, nil
+	}
+	return []Misconfiguration{{
+		ResourceType:   a.AssetType,
+		ResourceName:   a.DisplayName,
+		ResourceID:     a.Name,
+		Issue:          "Cluster nodes have public IP addresses",
+		Severity:       "HIGH",
+		Recommendation: "Recreate the cluster with private nodes enabled",
+		Category:       "GKE",
+	}}, nil
+}
+
+// kmsMatcher flags crypto keys with no automatic rotation configured.
+type kmsMatcher struct{}
+
+func (kmsMatcher) Family() string      { return "kms" }
+func (kmsMatcher) AssetTypes() []string { return []string{"cloudkms.googleapis.com/CryptoKey"} }
+
+func (kmsMatcher) Match(ctx context.Context, a Asset) ([]Misconfiguration, error) {
+	if a.Attributes["rotationPeriod"] != nil {
+		return nil, nil
+	}
+	return []Misconfiguration{{
+		ResourceType:   a.AssetType,
+		ResourceName:   a.DisplayName,
+		ResourceID:     a.Name,
+		Issue:          "Crypto key has no automatic rotation period configured",
+		Severity:       "MEDIUM",
+		Recommendation: "Set a rotationPeriod so the key material rotates automatically",
+		Category:       "KMS",
+	}}, nil
+}
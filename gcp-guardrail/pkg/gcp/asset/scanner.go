@@ -0,0 +1,245 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	assetapi "cloud.google.com/go/asset/apiv1"
+	"cloud.google.com/go/asset/apiv1/assetpb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/api/iterator"
+)
+
+// tracer and meter instrument Scan so scheduled runs can be observed for
+// API cost and finding volume via OTLP.
+var (
+	tracer = otel.Tracer("github.com/hieptle/gcp-guardrail/pkg/gcp/asset")
+	meter  = otel.Meter("github.com/hieptle/gcp-guardrail/pkg/gcp/asset")
+
+	findingsFoundCounter metric.Int64Counter
+	apiCallsCounter      metric.Int64Counter
+	apiLatencyHistogram  metric.Float64Histogram
+)
+
+func init() {
+	var err error
+	findingsFoundCounter, err = meter.Int64Counter(
+		"gcpgolang.asset.findings_found",
+		metric.WithDescription("Number of misconfigurations found per Scan run"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("asset: failed to register findings_found counter: %v", err))
+	}
+
+	apiCallsCounter, err = meter.Int64Counter(
+		"gcpgolang.asset.api_calls",
+		metric.WithDescription("Number of GCP API calls made by the asset scanner, by method"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("asset: failed to register api_calls counter: %v", err))
+	}
+
+	apiLatencyHistogram, err = meter.Float64Histogram(
+		"gcpgolang.asset.api_latency",
+		metric.WithDescription("Latency of GCP API calls made by the asset scanner, in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("asset: failed to register api_latency histogram: %v", err))
+	}
+}
+
+// recordAPICall records one API call's outcome and latency under the
+// api-calls and api-latency instruments, tagged with the method name and
+// whether it failed.
+func recordAPICall(ctx context.Context, method string, start time.Time, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.Bool("error", err != nil),
+	)
+	apiCallsCounter.Add(ctx, 1, attrs)
+	apiLatencyHistogram.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
+// Scanner enumerates GCP resources via Cloud Asset Inventory and
+// evaluates them against registered PolicyMatchers.
+type Scanner struct {
+	client *assetapi.Client
+}
+
+// NewScanner creates a Scanner backed by the real Cloud Asset Inventory API.
+func NewScanner(ctx context.Context) (*Scanner, error) {
+	client, err := assetapi.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud asset client: %w", err)
+	}
+	return &Scanner{client: client}, nil
+}
+
+// Close releases the underlying Cloud Asset client.
+func (s *Scanner) Close() error {
+	return s.client.Close()
+}
+
+// Scan resolves the matchers selected by scanType (SelectMatchers), walks
+// every asset under scope (e.g. "projects/my-project") that any selected
+// matcher cares about via SearchAllResources, and evaluates each asset
+// through its matcher. The iam family additionally pulls project-level
+// IAM bindings via SearchAllIamPolicies, since those aren't part of
+// SearchAllResources' own response.
+func (s *Scanner) Scan(ctx context.Context, scope, scanType string) ([]Misconfiguration, error) {
+	matchers, err := SelectMatchers(scanType)
+	if err != nil {
+		return nil, err
+	}
+
+	byAssetType := make(map[string]PolicyMatcher)
+	var assetTypes []string
+	var iamMatcherSelected PolicyMatcher
+	for _, m := range matchers {
+		if m.Family() == "iam" {
+			iamMatcherSelected = m
+		}
+		for _, t := range m.AssetTypes() {
+			byAssetType[t] = m
+			assetTypes = append(assetTypes, t)
+		}
+	}
+
+	var findings []Misconfiguration
+
+	resourceFindings, err := s.scanResources(ctx, scope, assetTypes, byAssetType)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, resourceFindings...)
+
+	if iamMatcherSelected != nil {
+		iamFindings, err := s.scanIAMPolicies(ctx, scope, iamMatcherSelected)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, iamFindings...)
+	}
+
+	findingsFoundCounter.Add(ctx, int64(len(findings)))
+	return findings, nil
+}
+
+// scanResources paginates SearchAllResources for assetTypes and runs each
+// result through the matcher registered for its asset type.
+func (s *Scanner) scanResources(ctx context.Context, scope string, assetTypes []string, byAssetType map[string]PolicyMatcher) (_ []Misconfiguration, err error) {
+	if len(assetTypes) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "SearchAllResources", start, err) }()
+
+	var findings []Misconfiguration
+
+	it := s.client.SearchAllResources(ctx, &assetpb.SearchAllResourcesRequest{
+		Scope:      scope,
+		AssetTypes: assetTypes,
+	})
+
+	for {
+		result, itErr := it.Next()
+		if itErr == iterator.Done {
+			break
+		}
+		if itErr != nil {
+			err = fmt.Errorf("failed to search resources: %w", itErr)
+			return nil, err
+		}
+
+		matcher, ok := byAssetType[result.AssetType]
+		if !ok {
+			continue
+		}
+
+		found, matchErr := matcher.Match(ctx, toAsset(result))
+		if matchErr != nil {
+			err = fmt.Errorf("matcher %q failed on %s: %w", matcher.Family(), result.Name, matchErr)
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+
+	return findings, nil
+}
+
+// scanIAMPolicies paginates SearchAllIamPolicies for scope, groups the
+// bindings by resource, and runs the iam matcher once per resource.
+func (s *Scanner) scanIAMPolicies(ctx context.Context, scope string, matcher PolicyMatcher) (_ []Misconfiguration, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "SearchAllIamPolicies", start, err) }()
+
+	bindingsByResource := make(map[string][]interface{})
+
+	it := s.client.SearchAllIamPolicies(ctx, &assetpb.SearchAllIamPoliciesRequest{
+		Scope: scope,
+	})
+
+	for {
+		result, itErr := it.Next()
+		if itErr == iterator.Done {
+			break
+		}
+		if itErr != nil {
+			err = fmt.Errorf("failed to search IAM policies: %w", itErr)
+			return nil, err
+		}
+		if result.Policy == nil {
+			continue
+		}
+		for _, binding := range result.Policy.Bindings {
+			members := make([]interface{}, 0, len(binding.Members))
+			for _, member := range binding.Members {
+				members = append(members, member)
+			}
+			bindingsByResource[result.Resource] = append(bindingsByResource[result.Resource], map[string]interface{}{
+				"role":    binding.Role,
+				"members": members,
+			})
+		}
+	}
+
+	var findings []Misconfiguration
+	for resource, bindings := range bindingsByResource {
+		found, matchErr := matcher.Match(ctx, Asset{
+			Name:       resource,
+			AssetType:  "cloudresourcemanager.googleapis.com/Project",
+			Attributes: map[string]interface{}{"bindings": bindings},
+		})
+		if matchErr != nil {
+			err = fmt.Errorf("matcher %q failed on %s: %w", matcher.Family(), resource, matchErr)
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+
+	return findings, nil
+}
+
+// toAsset flattens a CAI SearchAllResources result into the matchers'
+// common Asset shape.
+func toAsset(r *assetpb.ResourceSearchResult) Asset {
+	var attrs map[string]interface{}
+	if r.AdditionalAttributes != nil {
+		attrs = r.AdditionalAttributes.AsMap()
+	}
+
+	return Asset{
+		Name:        r.Name,
+		AssetType:   r.AssetType,
+		Project:     r.Project,
+		Location:    r.Location,
+		DisplayName: r.DisplayName,
+		Labels:      r.Labels,
+		Attributes:  attrs,
+	}
+}
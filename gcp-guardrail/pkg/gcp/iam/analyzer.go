@@ -3,12 +3,68 @@ package iam
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"cloud.google.com/go/iam/apiv1/iampb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/api/iam/v1"
 )
 
+// tracer and meter instrument AnalyzeProject so scheduled runs can be
+// observed for API cost and issue volume via OTLP.
+var (
+	tracer = otel.Tracer("github.com/hieptle/gcp-guardrail/pkg/gcp/iam")
+	meter  = otel.Meter("github.com/hieptle/gcp-guardrail/pkg/gcp/iam")
+
+	issuesFoundCounter metric.Int64Counter
+	apiCallsCounter    metric.Int64Counter
+	apiLatencyHistogram metric.Float64Histogram
+)
+
+func init() {
+	var err error
+	issuesFoundCounter, err = meter.Int64Counter(
+		"gcpgolang.iam.issues_found",
+		metric.WithDescription("Number of IAM issues found per AnalyzeProject run"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("iam: failed to register issues_found counter: %v", err))
+	}
+
+	apiCallsCounter, err = meter.Int64Counter(
+		"gcpgolang.iam.api_calls",
+		metric.WithDescription("Number of GCP API calls made by the IAM analyzer, by method"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("iam: failed to register api_calls counter: %v", err))
+	}
+
+	apiLatencyHistogram, err = meter.Float64Histogram(
+		"gcpgolang.iam.api_latency",
+		metric.WithDescription("Latency of GCP API calls made by the IAM analyzer, in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("iam: failed to register api_latency histogram: %v", err))
+	}
+}
+
+// recordAPICall records one API call's outcome and latency under the
+// api-calls and api-latency instruments, tagged with the method name and
+// whether it failed.
+func recordAPICall(ctx context.Context, method string, start time.Time, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.Bool("error", err != nil),
+	)
+	apiCallsCounter.Add(ctx, 1, attrs)
+	apiLatencyHistogram.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
 // Analysis represents the result of an IAM policy analysis
 type Analysis struct {
 	ProjectID         string
@@ -33,6 +89,9 @@ type Issue struct {
 	Principal   string
 	Role        string
 	Mitigation  string
+	// CheckName is the Check.Name() that produced this issue, e.g.
+	// "OwnerAtOrgLevel". Used as the ruleId in SARIF output.
+	CheckName string
 }
 
 // UnusedPermission represents a permission that hasn't been used in a specific time window
@@ -48,21 +107,97 @@ type UnusedPermission struct {
 type Analyzer struct {
 	projectID string
 	client    *iam.Service
+	fetcher   PolicyFetcher
 	ctx       context.Context
+
+	// includeChecks/skipChecks drive SelectChecks; leave both nil to run
+	// every registered Check.
+	includeChecks []string
+	skipChecks    []string
+
+	usageFetcher   PermissionUsageFetcher
+	usageWindow    time.Duration
+	recommendRoles bool
 }
 
-// NewAnalyzer creates a new IAM policy analyzer
+// NewAnalyzer creates a new IAM policy analyzer. It wires up a
+// CloudAssetFetcher wrapped in a disk cache under
+// $HOME/.gcp-guardrail/cache/iam so repeated runs against unchanged
+// policies skip the network round trip. Use NewAnalyzerWithFetcher to
+// inject a fake PolicyFetcher in tests.
 func NewAnalyzer(ctx context.Context, projectID string) (*Analyzer, error) {
 	client, err := iam.NewService(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create IAM client: %w", err)
 	}
 
+	assetFetcher, err := NewCloudAssetFetcher(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud asset fetcher: %w", err)
+	}
+
+	cacheDir, err := defaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, err := NewCachedFetcher(assetFetcher, cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cached policy fetcher: %w", err)
+	}
+
+	usageFetcher, err := NewCloudLoggingUsageFetcher(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create permission usage fetcher: %w", err)
+	}
+
+	analyzer := NewAnalyzerWithFetcher(ctx, projectID, client, fetcher)
+	analyzer.usageFetcher = usageFetcher
+	analyzer.usageWindow = defaultUsageWindow
+	return analyzer, nil
+}
+
+// defaultUsageWindow is how far back UnusedPermission analysis looks in
+// Cloud Audit Logs when --usage-window isn't set.
+const defaultUsageWindow = 90 * 24 * time.Hour
+
+// NewAnalyzerWithFetcher creates an Analyzer against an explicit
+// PolicyFetcher, bypassing the default CloudAssetFetcher+cache wiring.
+// Tests use this to inject fixture data.
+func NewAnalyzerWithFetcher(ctx context.Context, projectID string, client *iam.Service, fetcher PolicyFetcher) *Analyzer {
 	return &Analyzer{
 		projectID: projectID,
 		client:    client,
+		fetcher:   fetcher,
 		ctx:       ctx,
-	}, nil
+	}
+}
+
+func defaultCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory for cache: %w", err)
+	}
+	return filepath.Join(home, ".gcp-guardrail", "cache", "iam"), nil
+}
+
+// SetChecks restricts AnalyzeProject to the named checks (include) minus
+// any named in skip, mirroring the --checks/--skip-checks cobra flags. An
+// empty include list means "every registered check".
+func (a *Analyzer) SetChecks(include, skip []string) {
+	a.includeChecks = include
+	a.skipChecks = skip
+}
+
+// SetUsageAnalysis configures the unused-permissions pass: window is how
+// far back to query Cloud Audit Logs (defaults to 90 days if zero), and
+// recommendRoles turns on least-privilege UnusedPermission.Recommended
+// suggestions, which costs an extra RecommendRole lookup per principal.
+func (a *Analyzer) SetUsageAnalysis(window time.Duration, recommendRoles bool) {
+	if window > 0 {
+		a.usageWindow = window
+	}
+	a.recommendRoles = recommendRoles
 }
 
 // GetProjectPolicy retrieves the IAM policy for a GCP project
@@ -72,54 +207,148 @@ func (a *Analyzer) GetProjectPolicy() (*iampb.Policy, error) {
 	return &iampb.Policy{}, nil
 }
 
-// CheckOverprivilegedAccounts identifies accounts with excessive permissions
-func (a *Analyzer) CheckOverprivilegedAccounts() ([]Issue, error) {
-	// TODO: Implement logic to identify overprivileged accounts
-	return []Issue{}, nil
-}
+// buildPolicyGraphWithContext fetches bindings and service account/key data
+// for the project and assembles them into the PolicyGraph checks operate
+// on. It takes an explicit context so callers can pass one carrying an
+// OTel span.
+func (a *Analyzer) buildPolicyGraphWithContext(ctx context.Context) (PolicyGraph, error) {
+	bindings, err := a.fetcher.SearchAllIamPolicies(ctx, "projects/"+a.projectID)
+	if err != nil {
+		return PolicyGraph{}, fmt.Errorf("failed to search IAM policies: %w", err)
+	}
 
-// CheckDangerousRoleCombinations identifies dangerous combinations of roles
-func (a *Analyzer) CheckDangerousRoleCombinations() ([]Issue, error) {
-	// TODO: Implement logic to identify dangerous role combinations
-	return []Issue{}, nil
-}
+	serviceAccounts, err := a.fetcher.ListServiceAccounts(ctx, a.projectID)
+	if err != nil {
+		return PolicyGraph{}, fmt.Errorf("failed to list service accounts: %w", err)
+	}
 
-// CheckServiceAccountIssues identifies potential issues with service accounts
-func (a *Analyzer) CheckServiceAccountIssues() ([]Issue, error) {
-	// TODO: Implement logic to identify service account issues
-	return []Issue{}, nil
+	accounts := make([]*AccountInfo, 0, len(serviceAccounts))
+	for _, sa := range serviceAccounts {
+		keys, err := a.fetcher.ListServiceAccountKeys(ctx, sa.Name)
+		if err != nil {
+			return PolicyGraph{}, fmt.Errorf("failed to list keys for %s: %w", sa.Email, err)
+		}
+		accounts = append(accounts, &AccountInfo{Email: sa.Email, Keys: keys})
+	}
+
+	return PolicyGraph{
+		ProjectID: a.projectID,
+		Bindings:  bindings,
+		Accounts:  accounts,
+	}, nil
 }
 
-// AnalyzeProject performs a full IAM analysis on a GCP project
+// AnalyzeProject performs a full IAM analysis on a GCP project by running
+// every selected Check against the project's PolicyGraph.
 func (a *Analyzer) AnalyzeProject() (*Analysis, error) {
+	ctx, span := tracer.Start(a.ctx, "iam.AnalyzeProject")
+	defer span.End()
+
 	analysis := &Analysis{
 		ProjectID: a.projectID,
 		Timestamp: time.Now(),
 	}
 
-	// Get all role assignments
-	// TODO: Implement logic to get all role assignments
-
-	// Check for security issues
-	overPrivilegedIssues, err := a.CheckOverprivilegedAccounts()
+	graph, err := a.buildPolicyGraphWithContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check overprivileged accounts: %w", err)
+		return nil, err
 	}
-	analysis.Issues = append(analysis.Issues, overPrivilegedIssues...)
 
-	roleCombinationIssues, err := a.CheckDangerousRoleCombinations()
+	for _, b := range graph.Bindings {
+		analysis.RoleAssignments = append(analysis.RoleAssignments, RoleAssignment{
+			Principal: b.Principal,
+			Role:      b.Role,
+			Scope:     b.Scope,
+		})
+	}
+
+	checks, err := SelectChecks(a.includeChecks, a.skipChecks)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check dangerous role combinations: %w", err)
+		return nil, fmt.Errorf("failed to select checks: %w", err)
 	}
-	analysis.Issues = append(analysis.Issues, roleCombinationIssues...)
 
-	serviceAccountIssues, err := a.CheckServiceAccountIssues()
+	issues, err := RunChecks(ctx, checks, graph)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check service account issues: %w", err)
+		return nil, err
 	}
-	analysis.Issues = append(analysis.Issues, serviceAccountIssues...)
+	analysis.Issues = issues
+	issuesFoundCounter.Add(ctx, int64(len(issues)))
 
-	// TODO: Check for unused permissions
+	if a.usageFetcher != nil {
+		unused, err := a.computeUnusedPermissions(ctx, analysis.RoleAssignments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute unused permissions: %w", err)
+		}
+		analysis.UnusedPermissions = unused
+	}
 
 	return analysis, nil
-} 
\ No newline at end of file
+}
+
+// computeUnusedPermissions groups assignments by principal, expands each
+// principal's effective permissions from its granted roles, diffs them
+// against what Cloud Audit Logs show the principal actually exercised
+// over the configured usage window, and returns an UnusedPermission entry
+// per granted-but-unused permission. If a.recommendRoles is set, each
+// entry's Recommended field is filled in with the smallest predefined
+// role covering the principal's used permissions.
+func (a *Analyzer) computeUnusedPermissions(ctx context.Context, assignments []RoleAssignment) ([]UnusedPermission, error) {
+	window := a.usageWindow
+	if window <= 0 {
+		window = defaultUsageWindow
+	}
+	since := time.Now().Add(-window)
+
+	rolesByPrincipal := make(map[string][]string)
+	for _, ra := range assignments {
+		rolesByPrincipal[ra.Principal] = append(rolesByPrincipal[ra.Principal], ra.Role)
+	}
+
+	var unused []UnusedPermission
+	for principal, roles := range rolesByPrincipal {
+		effective := EffectivePermissions(roles)
+		if len(effective) == 0 {
+			continue
+		}
+
+		used, err := a.usageFetcher.UsedPermissions(ctx, principal, since)
+		if err != nil {
+			return nil, err
+		}
+
+		var recommended string
+		if a.recommendRoles {
+			recommended = RecommendRole(used)
+		}
+
+		diff := DiffPermissions(principal, effective, used)
+		for _, permission := range diff.UnusedPermissions {
+			unused = append(unused, UnusedPermission{
+				Principal:   principal,
+				Role:        roleGrantingPermission(roles, permission),
+				Permission:  permission,
+				Recommended: recommended,
+			})
+		}
+	}
+
+	return unused, nil
+}
+
+// roleGrantingPermission returns the first role in roles whose expansion
+// includes permission, for attributing an UnusedPermission back to the
+// binding that granted it. Falls back to the first role if none match
+// (e.g. the permission came from a wildcard expansion).
+func roleGrantingPermission(roles []string, permission string) string {
+	for _, role := range roles {
+		for _, p := range ExpandRole(role) {
+			if p == permission {
+				return role
+			}
+		}
+	}
+	if len(roles) > 0 {
+		return roles[0]
+	}
+	return ""
+}
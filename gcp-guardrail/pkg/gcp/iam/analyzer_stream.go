@@ -0,0 +1,92 @@
+package iam
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnalyzeProjectStreaming performs the same analysis as AnalyzeProject, but
+// writes role assignments and issues to w as they're discovered instead of
+// first accumulating them into an Analysis's slices, avoiding one
+// intermediate full-size copy on the writer side.
+//
+// This is NOT constant memory end to end: buildPolicyGraphWithContext calls
+// the batch fetcher.SearchAllIamPolicies, which materializes every binding
+// into a single []PolicyBinding before this method ever reaches its first
+// WriteRoleAssignment call, and RunChecks needs that same full PolicyGraph
+// to run checks that correlate across bindings (e.g.
+// dangerousRoleCombinationCheck). So peak memory for an org-wide analysis is
+// still proportional to its total binding count. Making this genuinely
+// constant-memory would mean having PolicyFetcher expose a page-at-a-time
+// binding iterator and splitting checks into the ones that can run
+// per-binding as pages arrive versus the ones that need the full graph.
+func (a *Analyzer) AnalyzeProjectStreaming(w StreamingReportWriter) error {
+	ctx, span := tracer.Start(a.ctx, "iam.AnalyzeProjectStreaming")
+	defer span.End()
+
+	if err := w.WriteHeader(ReportMeta{ProjectID: a.projectID, GeneratedAt: time.Now()}); err != nil {
+		return fmt.Errorf("failed to write report header: %w", err)
+	}
+
+	graph, err := a.buildPolicyGraphWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	principals := make(map[string]bool)
+	stats := Stats{}
+	var assignments []RoleAssignment
+	for _, b := range graph.Bindings {
+		ra := RoleAssignment{Principal: b.Principal, Role: b.Role, Scope: b.Scope}
+		if err := w.WriteRoleAssignment(ra); err != nil {
+			return fmt.Errorf("failed to write role assignment: %w", err)
+		}
+		assignments = append(assignments, ra)
+		principals[b.Principal] = true
+		stats.TotalRoles++
+	}
+	stats.TotalPrincipals = len(principals)
+
+	checks, err := SelectChecks(a.includeChecks, a.skipChecks)
+	if err != nil {
+		return fmt.Errorf("failed to select checks: %w", err)
+	}
+
+	issues, err := RunChecks(ctx, checks, graph)
+	if err != nil {
+		return err
+	}
+	issuesFoundCounter.Add(ctx, int64(len(issues)))
+
+	for _, issue := range issues {
+		if err := w.WriteIssue(issue); err != nil {
+			return fmt.Errorf("failed to write issue: %w", err)
+		}
+		stats.TotalIssues++
+		switch issue.Severity {
+		case "CRITICAL":
+			stats.CriticalIssues++
+		case "HIGH":
+			stats.HighIssues++
+		case "MEDIUM":
+			stats.MediumIssues++
+		case "LOW":
+			stats.LowIssues++
+		}
+	}
+
+	if a.usageFetcher != nil {
+		unused, err := a.computeUnusedPermissions(ctx, assignments)
+		if err != nil {
+			return fmt.Errorf("failed to compute unused permissions: %w", err)
+		}
+		for _, up := range unused {
+			if err := w.WriteUnusedPermission(up); err != nil {
+				return fmt.Errorf("failed to write unused permission: %w", err)
+			}
+			stats.UnusedPermissions++
+		}
+	}
+
+	return w.WriteFooter(stats)
+}
@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/api/iam/v1"
+)
+
+// fixturePolicyFetcher is a PolicyFetcher backed by in-memory fixture data,
+// for exercising the Analyzer without talking to any GCP API.
+type fixturePolicyFetcher struct {
+	bindings []PolicyBinding
+	accounts []*iam.ServiceAccount
+	keys     map[string][]ServiceAccountKey
+}
+
+func (f *fixturePolicyFetcher) SearchAllIamPolicies(ctx context.Context, scope string) ([]PolicyBinding, error) {
+	return f.bindings, nil
+}
+
+func (f *fixturePolicyFetcher) ListServiceAccounts(ctx context.Context, projectID string) ([]*iam.ServiceAccount, error) {
+	return f.accounts, nil
+}
+
+func (f *fixturePolicyFetcher) ListServiceAccountKeys(ctx context.Context, serviceAccountResource string) ([]ServiceAccountKey, error) {
+	return f.keys[serviceAccountResource], nil
+}
+
+// fixtureUsageFetcher is a PermissionUsageFetcher backed by a fixed
+// per-principal permission set, for exercising computeUnusedPermissions
+// without talking to Cloud Audit Logs.
+type fixtureUsageFetcher struct {
+	used map[string][]string
+}
+
+func (f *fixtureUsageFetcher) UsedPermissions(ctx context.Context, principal string, since time.Time) ([]string, error) {
+	return f.used[principal], nil
+}
+
+func TestAnalyzeProjectDangerousRoleCombination(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindings    []PolicyBinding
+		wantFlagged bool
+	}{
+		{
+			name: "same principal holds both roles",
+			bindings: []PolicyBinding{
+				{Principal: "serviceAccount:a@p.iam.gserviceaccount.com", Role: "roles/iam.serviceAccountTokenCreator", Scope: "projects/p", Resource: "projects/p"},
+				{Principal: "serviceAccount:a@p.iam.gserviceaccount.com", Role: "roles/iam.serviceAccountUser", Scope: "projects/p", Resource: "projects/p"},
+			},
+			wantFlagged: true,
+		},
+		{
+			name: "roles split across different principals",
+			bindings: []PolicyBinding{
+				{Principal: "serviceAccount:a@p.iam.gserviceaccount.com", Role: "roles/iam.serviceAccountTokenCreator", Scope: "projects/p", Resource: "projects/p"},
+				{Principal: "serviceAccount:b@p.iam.gserviceaccount.com", Role: "roles/iam.serviceAccountUser", Scope: "projects/p", Resource: "projects/p"},
+			},
+			wantFlagged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &fixturePolicyFetcher{bindings: tt.bindings}
+			analyzer := NewAnalyzerWithFetcher(context.Background(), "p", nil, fetcher)
+			analyzer.SetChecks([]string{"DangerousRoleCombination"}, nil)
+
+			analysis, err := analyzer.AnalyzeProject()
+			if err != nil {
+				t.Fatalf("AnalyzeProject() returned error: %v", err)
+			}
+
+			var flagged bool
+			for _, issue := range analysis.Issues {
+				if issue.CheckName == "DangerousRoleCombination" {
+					flagged = true
+				}
+			}
+			if flagged != tt.wantFlagged {
+				t.Errorf("DangerousRoleCombination flagged = %v, want %v (issues: %+v)", flagged, tt.wantFlagged, analysis.Issues)
+			}
+		})
+	}
+}
+
+func TestAnalyzeProjectUnusedPermissions(t *testing.T) {
+	const principal = "user:alice@example.com"
+
+	fetcher := &fixturePolicyFetcher{
+		bindings: []PolicyBinding{
+			{Principal: principal, Role: "roles/viewer", Scope: "projects/p", Resource: "projects/p"},
+		},
+	}
+	analyzer := NewAnalyzerWithFetcher(context.Background(), "p", nil, fetcher)
+	analyzer.SetChecks(nil, CheckNames()) // no Check findings needed for this test
+	analyzer.usageFetcher = &fixtureUsageFetcher{
+		used: map[string][]string{
+			principal: {"resourcemanager.projects.get"},
+		},
+	}
+	analyzer.usageWindow = 90 * 24 * time.Hour
+
+	analysis, err := analyzer.AnalyzeProject()
+	if err != nil {
+		t.Fatalf("AnalyzeProject() returned error: %v", err)
+	}
+
+	var unusedPermissions []string
+	for _, u := range analysis.UnusedPermissions {
+		if u.Principal != principal {
+			t.Errorf("UnusedPermission for unexpected principal %q", u.Principal)
+		}
+		unusedPermissions = append(unusedPermissions, u.Permission)
+	}
+	sort.Strings(unusedPermissions)
+
+	want := []string{"iam.serviceAccounts.get", "iam.serviceAccounts.list"}
+	if len(unusedPermissions) != len(want) {
+		t.Fatalf("UnusedPermissions = %v, want %v", unusedPermissions, want)
+	}
+	for i, p := range want {
+		if unusedPermissions[i] != p {
+			t.Errorf("UnusedPermissions[%d] = %q, want %q", i, unusedPermissions[i], p)
+		}
+	}
+}
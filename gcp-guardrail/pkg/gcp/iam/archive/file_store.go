@@ -0,0 +1,143 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileStore is the default Store: one JSON file per archived report,
+// laid out as <baseDir>/<projectID>/<unix-nanos>.json.
+type FileStore struct {
+	baseDir string
+}
+
+// NewFileStore creates a FileStore rooted at baseDir, creating it if
+// needed. An empty baseDir uses DefaultDir.
+func NewFileStore(baseDir string) (*FileStore, error) {
+	if baseDir == "" {
+		var err error
+		baseDir, err = DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return &FileStore{baseDir: baseDir}, nil
+}
+
+// DefaultDir returns $HOME/.gcp-guardrail/archives, the default FileStore
+// location used when no archive directory is configured.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".gcp-guardrail", "archives"), nil
+}
+
+// archiveFileName and parseArchiveFileName encode/decode a report's
+// archive timestamp as its file basename, mirroring how
+// pkg/gcp/iam/baseline's GCSStore keys snapshot objects.
+func archiveFileName(timestamp time.Time) string {
+	return strconv.FormatInt(timestamp.UnixNano(), 10) + ".json"
+}
+
+func parseArchiveFileName(name string) (time.Time, bool) {
+	nanos, err := strconv.ParseInt(strings.TrimSuffix(name, ".json"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+func (s *FileStore) projectDir(projectID string) string {
+	return filepath.Join(s.baseDir, projectID)
+}
+
+// Save writes data as the archived report for projectID at timestamp.
+func (s *FileStore) Save(projectID string, timestamp time.Time, data []byte) error {
+	dir := s.projectDir(projectID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory for %s: %w", projectID, err)
+	}
+	path := filepath.Join(dir, archiveFileName(timestamp))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write archived report: %w", err)
+	}
+	return nil
+}
+
+// Load returns the archived report for projectID matching ref: "latest"
+// for the most recent archive, or an RFC3339 timestamp matching a prior
+// Save.
+func (s *FileStore) Load(projectID, ref string) ([]byte, time.Time, error) {
+	history, err := s.historyFiles(projectID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(history) == 0 {
+		return nil, time.Time{}, ErrNotFound
+	}
+
+	var target time.Time
+	if ref == "" || ref == "latest" {
+		target = history[len(history)-1]
+	} else {
+		parsed, err := time.Parse(time.RFC3339, ref)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("archive: invalid ref %q: %w", ref, err)
+		}
+		found := false
+		for _, ts := range history {
+			if ts.Equal(parsed) {
+				target = ts
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, time.Time{}, ErrNotFound
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.projectDir(projectID), archiveFileName(target)))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read archived report: %w", err)
+	}
+	return data, target, nil
+}
+
+// History returns the timestamps of every report archived for
+// projectID, oldest first.
+func (s *FileStore) History(projectID string) ([]time.Time, error) {
+	return s.historyFiles(projectID)
+}
+
+func (s *FileStore) historyFiles(projectID string) ([]time.Time, error) {
+	entries, err := os.ReadDir(s.projectDir(projectID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived reports for %s: %w", projectID, err)
+	}
+
+	var history []time.Time
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if ts, ok := parseArchiveFileName(entry.Name()); ok {
+			history = append(history, ts)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Before(history[j]) })
+	return history, nil
+}
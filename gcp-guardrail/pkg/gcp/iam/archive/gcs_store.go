@@ -0,0 +1,131 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// GCSStore is a Store backed by a GCS bucket, for teams that want
+// archived reports to survive across CI runners rather than living on
+// one machine's disk. Report objects are laid out as
+// <prefix>/<projectID>/<unix-nanos>.json.
+type GCSStore struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// NewGCSStore creates a GCSStore writing archived report objects under
+// gs://bucket/prefix/.
+func NewGCSStore(ctx context.Context, bucket, prefix string) (*GCSStore, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &GCSStore{bucket: client.Bucket(bucket), prefix: strings.Trim(prefix, "/")}, nil
+}
+
+func (g *GCSStore) objectName(projectID string, timestamp time.Time) string {
+	name := fmt.Sprintf("%s/%s", projectID, archiveFileName(timestamp))
+	if g.prefix == "" {
+		return name
+	}
+	return g.prefix + "/" + name
+}
+
+func (g *GCSStore) projectPrefix(projectID string) string {
+	if g.prefix == "" {
+		return projectID + "/"
+	}
+	return g.prefix + "/" + projectID + "/"
+}
+
+// Save writes data as the archived report object for projectID at
+// timestamp.
+func (g *GCSStore) Save(projectID string, timestamp time.Time, data []byte) error {
+	ctx := context.Background()
+	w := g.bucket.Object(g.objectName(projectID, timestamp)).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write archived report to GCS: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archived report in GCS: %w", err)
+	}
+	return nil
+}
+
+// Load returns the archived report for projectID matching ref: "latest"
+// for the most recent archive, or an RFC3339 timestamp matching a prior
+// Save.
+func (g *GCSStore) Load(projectID, ref string) ([]byte, time.Time, error) {
+	history, err := g.History(projectID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(history) == 0 {
+		return nil, time.Time{}, ErrNotFound
+	}
+
+	target := history[len(history)-1]
+	if ref != "" && ref != "latest" {
+		parsed, err := time.Parse(time.RFC3339, ref)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("archive: invalid ref %q: %w", ref, err)
+		}
+		found := false
+		for _, ts := range history {
+			if ts.Equal(parsed) {
+				target = ts
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, time.Time{}, ErrNotFound
+		}
+	}
+
+	ctx := context.Background()
+	r, err := g.bucket.Object(g.objectName(projectID, target)).NewReader(ctx)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read archived report from GCS: %w", err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read archived report from GCS: %w", err)
+	}
+	return data, target, nil
+}
+
+// History returns the timestamps of every report archived for
+// projectID, oldest first.
+func (g *GCSStore) History(projectID string) ([]time.Time, error) {
+	ctx := context.Background()
+	it := g.bucket.Objects(ctx, &storage.Query{Prefix: g.projectPrefix(projectID)})
+
+	var history []time.Time
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list archived reports in GCS: %w", err)
+		}
+		base := attrs.Name[strings.LastIndex(attrs.Name, "/")+1:]
+		if ts, ok := parseArchiveFileName(base); ok {
+			history = append(history, ts)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Before(history[j]) })
+	return history, nil
+}
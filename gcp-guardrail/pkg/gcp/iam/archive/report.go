@@ -0,0 +1,60 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/sa"
+)
+
+// SaveIAMReport JSON-encodes report and archives it in store for
+// report.ProjectID at timestamp.
+func SaveIAMReport(store Store, timestamp time.Time, report *iam.Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal IAM report for archive: %w", err)
+	}
+	return store.Save(report.ProjectID, timestamp, data)
+}
+
+// LoadIAMReport returns the archived IAM report for projectID matching
+// ref ("latest" or an RFC3339 timestamp).
+func LoadIAMReport(store Store, projectID, ref string) (*iam.Report, time.Time, error) {
+	data, timestamp, err := store.Load(projectID, ref)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var report iam.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode archived IAM report: %w", err)
+	}
+	return &report, timestamp, nil
+}
+
+// SaveSAReport JSON-encodes report and archives it in store for
+// report.ProjectID at timestamp.
+func SaveSAReport(store Store, timestamp time.Time, report *sa.Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal service account report for archive: %w", err)
+	}
+	return store.Save(report.ProjectID, timestamp, data)
+}
+
+// LoadSAReport returns the archived service account report for
+// projectID matching ref ("latest" or an RFC3339 timestamp).
+func LoadSAReport(store Store, projectID, ref string) (*sa.Report, time.Time, error) {
+	data, timestamp, err := store.Load(projectID, ref)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var report sa.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode archived service account report: %w", err)
+	}
+	return &report, timestamp, nil
+}
@@ -0,0 +1,37 @@
+// Package archive persists every IAM/SA analysis run a user chooses to
+// keep, rather than just the most recent one, so `--diff-against <ref>`
+// and the iam-server history endpoints can compare against any point in
+// a project's history rather than only "current vs. last run" (that
+// narrower case is handled by pkg/gcp/iam/baseline).
+//
+// FileStore is the default, zero-config Store, laying reports out under
+// $HOME/.gcp-guardrail/archives/<project>/<timestamp>.json. GCSStore is a
+// pluggable alternative for teams that want archived reports to survive
+// across CI runners rather than living on one machine's disk.
+package archive
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when ref doesn't match any archived report for
+// a project.
+var ErrNotFound = errors.New("archive: no report found for the given ref")
+
+// Store persists and retrieves archived reports for a project, each
+// keyed by the time it was archived.
+type Store interface {
+	// Save persists data (typically a JSON-encoded iam.Report or
+	// sa.Report) as the archived report for projectID at timestamp.
+	Save(projectID string, timestamp time.Time, data []byte) error
+
+	// Load returns the archived report for projectID matching ref. ref
+	// is either "latest" or an RFC3339 timestamp matching a prior Save.
+	// It returns ErrNotFound if ref doesn't resolve to anything archived.
+	Load(projectID, ref string) ([]byte, time.Time, error)
+
+	// History returns the timestamps of every report archived for
+	// projectID, oldest first.
+	History(projectID string) ([]time.Time, error)
+}
@@ -0,0 +1,122 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// snapshotObjectName and parseSnapshotObjectName encode/decode a
+// snapshot's timestamp as its object basename, mirroring how SQLiteStore
+// keys rows by timestamp_ns.
+func snapshotObjectName(timestamp time.Time) string {
+	return strconv.FormatInt(timestamp.UnixNano(), 10) + ".json"
+}
+
+func parseSnapshotObjectName(name string) (time.Time, bool) {
+	nanos, err := strconv.ParseInt(strings.TrimSuffix(name, ".json"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// GCSStore is a Store backed by a GCS bucket, for teams that run
+// gcp-guardrail from multiple machines or CI jobs and need a shared
+// baseline rather than SQLiteStore's local database. Snapshot objects
+// are laid out as <projectID>/<unix-nanos>.json.
+type GCSStore struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// NewGCSStore creates a GCSStore writing snapshot objects under
+// gs://bucket/prefix/.
+func NewGCSStore(ctx context.Context, bucket, prefix string) (*GCSStore, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &GCSStore{bucket: client.Bucket(bucket), prefix: strings.Trim(prefix, "/")}, nil
+}
+
+func (g *GCSStore) objectName(projectID string, timestamp time.Time) string {
+	name := fmt.Sprintf("%s/%s", projectID, snapshotObjectName(timestamp))
+	if g.prefix == "" {
+		return name
+	}
+	return g.prefix + "/" + name
+}
+
+func (g *GCSStore) projectPrefix(projectID string) string {
+	if g.prefix == "" {
+		return projectID + "/"
+	}
+	return g.prefix + "/" + projectID + "/"
+}
+
+// Save writes data as the snapshot object for projectID at timestamp.
+func (g *GCSStore) Save(projectID string, timestamp time.Time, data []byte) error {
+	ctx := context.Background()
+	w := g.bucket.Object(g.objectName(projectID, timestamp)).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write baseline snapshot to GCS: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finalize baseline snapshot in GCS: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent snapshot object for projectID at or
+// before before.
+func (g *GCSStore) Latest(projectID string, before time.Time) ([]byte, time.Time, error) {
+	ctx := context.Background()
+	it := g.bucket.Objects(ctx, &storage.Query{Prefix: g.projectPrefix(projectID)})
+
+	var latest time.Time
+	var latestName string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to list baseline snapshots in GCS: %w", err)
+		}
+
+		base := attrs.Name[strings.LastIndex(attrs.Name, "/")+1:]
+		ts, ok := parseSnapshotObjectName(base)
+		if !ok || ts.After(before) {
+			continue
+		}
+		if ts.After(latest) {
+			latest = ts
+			latestName = attrs.Name
+		}
+	}
+	if latestName == "" {
+		return nil, time.Time{}, ErrNoSnapshot
+	}
+
+	r, err := g.bucket.Object(latestName).NewReader(ctx)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read baseline snapshot from GCS: %w", err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read baseline snapshot from GCS: %w", err)
+	}
+
+	return data, latest, nil
+}
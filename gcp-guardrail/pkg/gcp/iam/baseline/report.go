@@ -0,0 +1,60 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/iam"
+	"github.com/hieptle/gcp-guardrail/pkg/gcp/sa"
+)
+
+// SaveIAMReport JSON-encodes report and persists it in store as the
+// snapshot for report.ProjectID at timestamp.
+func SaveIAMReport(store Store, timestamp time.Time, report *iam.Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal IAM report for baseline: %w", err)
+	}
+	return store.Save(report.ProjectID, timestamp, data)
+}
+
+// LatestIAMReport returns the most recent IAM report snapshot for
+// projectID at or before before.
+func LatestIAMReport(store Store, projectID string, before time.Time) (*iam.Report, time.Time, error) {
+	data, timestamp, err := store.Latest(projectID, before)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var report iam.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode IAM baseline snapshot: %w", err)
+	}
+	return &report, timestamp, nil
+}
+
+// SaveSAReport JSON-encodes report and persists it in store as the
+// snapshot for report.ProjectID at timestamp.
+func SaveSAReport(store Store, timestamp time.Time, report *sa.Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal service account report for baseline: %w", err)
+	}
+	return store.Save(report.ProjectID, timestamp, data)
+}
+
+// LatestSAReport returns the most recent service account report
+// snapshot for projectID at or before before.
+func LatestSAReport(store Store, projectID string, before time.Time) (*sa.Report, time.Time, error) {
+	data, timestamp, err := store.Latest(projectID, before)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var report sa.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode service account baseline snapshot: %w", err)
+	}
+	return &report, timestamp, nil
+}
@@ -0,0 +1,95 @@
+package baseline
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite" // pure-Go driver; keeps the CLI a single static binary
+)
+
+// SQLiteStore is the default Store: a local SQLite database holding one
+// row per (project_id, timestamp) snapshot.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if needed) a SQLite database at path and
+// ensures its schema exists.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open baseline database: %w", err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS snapshots (
+	project_id   TEXT NOT NULL,
+	timestamp_ns INTEGER NOT NULL,
+	data         BLOB NOT NULL,
+	PRIMARY KEY (project_id, timestamp_ns)
+);`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize baseline schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// DefaultPath returns $HOME/.gcp-guardrail/baselines/<kind>.db, the
+// default SQLiteStore location used when no store location is
+// configured. kind is typically "iam" or "sa".
+func DefaultPath(kind string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".gcp-guardrail", "baselines", kind+".db"), nil
+}
+
+// Save persists data as the snapshot for projectID at timestamp.
+func (s *SQLiteStore) Save(projectID string, timestamp time.Time, data []byte) error {
+	_, err := s.db.Exec(
+		`INSERT OR REPLACE INTO snapshots (project_id, timestamp_ns, data) VALUES (?, ?, ?)`,
+		projectID, timestamp.UnixNano(), data,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save baseline snapshot: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent snapshot for projectID at or before
+// before.
+func (s *SQLiteStore) Latest(projectID string, before time.Time) ([]byte, time.Time, error) {
+	var data []byte
+	var timestampNs int64
+
+	row := s.db.QueryRow(
+		`SELECT data, timestamp_ns FROM snapshots WHERE project_id = ? AND timestamp_ns <= ? ORDER BY timestamp_ns DESC LIMIT 1`,
+		projectID, before.UnixNano(),
+	)
+	if err := row.Scan(&data, &timestampNs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, time.Time{}, ErrNoSnapshot
+		}
+		return nil, time.Time{}, fmt.Errorf("failed to load baseline snapshot: %w", err)
+	}
+
+	return data, time.Unix(0, timestampNs), nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
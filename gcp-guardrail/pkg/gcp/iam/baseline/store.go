@@ -0,0 +1,32 @@
+// Package baseline persists historical IAM and service account reports so
+// later runs can detect what changed since a prior point in time
+// ("drift").
+//
+// SQLiteStore is the default, zero-config Store, holding one row per
+// (project_id, timestamp) snapshot in a local database under
+// $HOME/.gcp-guardrail. GCSStore is a pluggable alternative for teams
+// that run the analyzer from multiple machines or CI jobs and need a
+// shared baseline.
+package baseline
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoSnapshot is returned by Latest when no snapshot exists for a
+// project at or before the requested time.
+var ErrNoSnapshot = errors.New("baseline: no snapshot found before the given time")
+
+// Store persists and retrieves IAM analysis snapshots for a project,
+// keyed by the time the snapshot was taken.
+type Store interface {
+	// Save persists data (typically a JSON-encoded iam.Report) as the
+	// snapshot for projectID at timestamp.
+	Save(projectID string, timestamp time.Time, data []byte) error
+
+	// Latest returns the most recent snapshot for projectID taken at or
+	// before before, along with the time it was taken. It returns
+	// ErrNoSnapshot if none exists.
+	Latest(projectID string, before time.Time) ([]byte, time.Time, error)
+}
@@ -0,0 +1,105 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// PolicyGraph is the input every Check operates on: the flattened set of
+// IAM bindings for a project plus the service accounts and keys gathered
+// alongside them. It is deliberately a plain snapshot rather than a live
+// client so checks stay easy to unit test.
+type PolicyGraph struct {
+	ProjectID string
+	Bindings  []PolicyBinding
+	Accounts  []*AccountInfo
+}
+
+// AccountInfo is the subset of service account + key data a Check needs.
+type AccountInfo struct {
+	Email string
+	Keys  []ServiceAccountKey
+}
+
+// Check is a single, self-contained risk rule. Implementations register
+// themselves via RegisterCheck so the set of checks run by the Analyzer is
+// driven by what's linked in, not a hardcoded switch/case.
+type Check interface {
+	// Name is the stable identifier used by --checks/--skip-checks and by
+	// report formats that need a rule id (e.g. SARIF's ruleId).
+	Name() string
+	// Analyze evaluates the check against graph and returns any issues found.
+	Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error)
+}
+
+var registry = map[string]Check{}
+
+// RegisterCheck adds a Check to the default registry. Checks call this from
+// an init() function so importing the iam package is enough to enable them.
+func RegisterCheck(c Check) {
+	if _, exists := registry[c.Name()]; exists {
+		panic(fmt.Sprintf("iam: check %q registered twice", c.Name()))
+	}
+	registry[c.Name()] = c
+}
+
+// CheckNames returns the names of every registered check, sorted for
+// deterministic --checks/--skip-checks validation and help output.
+func CheckNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SelectChecks resolves the checks to run given --checks/--skip-checks
+// values. An empty include list means "all registered checks". Skip is
+// applied after include.
+func SelectChecks(include, skip []string) ([]Check, error) {
+	selected := registry
+	if len(include) > 0 {
+		selected = make(map[string]Check, len(include))
+		for _, name := range include {
+			c, ok := registry[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown check %q", name)
+			}
+			selected[name] = c
+		}
+	}
+
+	skipSet := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipSet[name] = true
+	}
+
+	var checks []Check
+	for name, c := range selected {
+		if skipSet[name] {
+			continue
+		}
+		checks = append(checks, c)
+	}
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name() < checks[j].Name() })
+	return checks, nil
+}
+
+// RunChecks runs every check in checks against graph and concatenates their issues.
+func RunChecks(ctx context.Context, checks []Check, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, c := range checks {
+		found, err := c.Analyze(ctx, graph)
+		if err != nil {
+			return nil, fmt.Errorf("check %q failed: %w", c.Name(), err)
+		}
+		for i := range found {
+			found[i].CheckName = c.Name()
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
@@ -0,0 +1,209 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterCheck(ownerAtOrgLevelCheck{})
+	RegisterCheck(broadServiceAccountRoleCheck{})
+	RegisterCheck(keyAgeExceedsPolicyCheck{maxAge: 90 * 24 * time.Hour})
+	RegisterCheck(publicAllUsersBindingCheck{})
+	RegisterCheck(primitiveRoleAssignmentCheck{})
+	RegisterCheck(crossProjectViewerCheck{})
+	RegisterCheck(dangerousRoleCombinationCheck{})
+}
+
+// ownerAtOrgLevelCheck flags any principal holding roles/owner at an
+// organization scope, the single highest-blast-radius binding GCP allows.
+type ownerAtOrgLevelCheck struct{}
+
+func (ownerAtOrgLevelCheck) Name() string { return "OwnerAtOrgLevel" }
+
+func (ownerAtOrgLevelCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, b := range graph.Bindings {
+		if b.Role == "roles/owner" && strings.HasPrefix(b.Scope, "organizations/") {
+			issues = append(issues, Issue{
+				Severity:    "CRITICAL",
+				Description: "Principal has Owner role at organization level",
+				Principal:   b.Principal,
+				Role:        b.Role,
+				Mitigation:  "Remove Owner role and grant more specific roles scoped to a project",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// broadServiceAccountRoleCheck flags service accounts granted Owner or
+// Editor, which is almost always broader than the automation needs.
+type broadServiceAccountRoleCheck struct{}
+
+func (broadServiceAccountRoleCheck) Name() string { return "BroadServiceAccountRole" }
+
+func (broadServiceAccountRoleCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, b := range graph.Bindings {
+		if !strings.HasPrefix(b.Principal, "serviceAccount:") {
+			continue
+		}
+		if b.Role == "roles/owner" || b.Role == "roles/editor" {
+			issues = append(issues, Issue{
+				Severity:    "HIGH",
+				Description: "Service account has a broad primitive role",
+				Principal:   b.Principal,
+				Role:        b.Role,
+				Mitigation:  "Grant only the predefined or custom roles the service account actually needs",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// keyAgeExceedsPolicyCheck flags user-managed service account keys older
+// than maxAge, since long-lived keys are the hardest credential to rotate
+// or revoke quickly after a leak.
+type keyAgeExceedsPolicyCheck struct {
+	maxAge time.Duration
+}
+
+func (keyAgeExceedsPolicyCheck) Name() string { return "KeyAgeExceedsPolicy" }
+
+func (c keyAgeExceedsPolicyCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, account := range graph.Accounts {
+		for _, key := range account.Keys {
+			if key.KeyType != "USER_MANAGED" {
+				continue
+			}
+			validAfter, err := time.Parse(time.RFC3339, key.ValidAfter)
+			if err != nil {
+				continue
+			}
+			if time.Since(validAfter) > c.maxAge {
+				issues = append(issues, Issue{
+					Severity:    "MEDIUM",
+					Description: "Service account key exceeds the maximum allowed age",
+					Principal:   "serviceAccount:" + account.Email,
+					Role:        "",
+					Mitigation:  "Rotate or delete the key and prefer short-lived credentials",
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// publicAllUsersBindingCheck flags any binding that grants allUsers or
+// allAuthenticatedUsers a role, which makes the bound resource public.
+type publicAllUsersBindingCheck struct{}
+
+func (publicAllUsersBindingCheck) Name() string { return "PublicAllUsersBinding" }
+
+func (publicAllUsersBindingCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, b := range graph.Bindings {
+		if b.Principal == "allUsers" || b.Principal == "allAuthenticatedUsers" {
+			issues = append(issues, Issue{
+				Severity:    "CRITICAL",
+				Description: "Role is bound to " + b.Principal + ", making the resource publicly accessible",
+				Principal:   b.Principal,
+				Role:        b.Role,
+				Mitigation:  "Remove the public binding and grant access to specific principals instead",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// primitiveRoleAssignmentCheck flags any use of the legacy primitive roles
+// (owner/editor/viewer), which Google's own hardening guidance recommends
+// against in favor of predefined roles.
+type primitiveRoleAssignmentCheck struct{}
+
+func (primitiveRoleAssignmentCheck) Name() string { return "PrimitiveRoleAssignment" }
+
+func (primitiveRoleAssignmentCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	for _, b := range graph.Bindings {
+		switch b.Role {
+		case "roles/owner", "roles/editor", "roles/viewer":
+			issues = append(issues, Issue{
+				Severity:    "LOW",
+				Description: "Principal is granted a legacy primitive role",
+				Principal:   b.Principal,
+				Role:        b.Role,
+				Mitigation:  "Replace the primitive role with the narrowest predefined role that covers the use case",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// crossProjectViewerCheck flags principals holding roles/viewer on a scope
+// outside the project being analyzed, a common sign of over-broad access
+// requests granted for convenience.
+type crossProjectViewerCheck struct{}
+
+func (crossProjectViewerCheck) Name() string { return "CrossProjectViewer" }
+
+func (crossProjectViewerCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	var issues []Issue
+	ownScope := "projects/" + graph.ProjectID
+	for _, b := range graph.Bindings {
+		if b.Role == "roles/viewer" && b.Scope != ownScope {
+			issues = append(issues, Issue{
+				Severity:    "LOW",
+				Description: "Principal has viewer access to a scope outside the analyzed project",
+				Principal:   b.Principal,
+				Role:        b.Role,
+				Mitigation:  "Review whether cross-project viewer access is still required",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// dangerousRoleCombinationCheck flags any principal holding both
+// roles/iam.serviceAccountTokenCreator and roles/iam.serviceAccountUser,
+// which together let the principal mint short-lived tokens for, and
+// otherwise act as, any service account it can impersonate — a common
+// privilege-escalation path since neither role alone grants that.
+type dangerousRoleCombinationCheck struct{}
+
+func (dangerousRoleCombinationCheck) Name() string { return "DangerousRoleCombination" }
+
+func (dangerousRoleCombinationCheck) Analyze(ctx context.Context, graph PolicyGraph) ([]Issue, error) {
+	const (
+		tokenCreatorRole = "roles/iam.serviceAccountTokenCreator"
+		accountUserRole  = "roles/iam.serviceAccountUser"
+	)
+
+	rolesByPrincipal := make(map[string]map[string]bool)
+	for _, b := range graph.Bindings {
+		if b.Role != tokenCreatorRole && b.Role != accountUserRole {
+			continue
+		}
+		if rolesByPrincipal[b.Principal] == nil {
+			rolesByPrincipal[b.Principal] = make(map[string]bool)
+		}
+		rolesByPrincipal[b.Principal][b.Role] = true
+	}
+
+	var issues []Issue
+	for principal, roles := range rolesByPrincipal {
+		if roles[tokenCreatorRole] && roles[accountUserRole] {
+			issues = append(issues, Issue{
+				Severity:    "HIGH",
+				Description: "Principal holds both serviceAccountTokenCreator and serviceAccountUser, allowing it to impersonate any service account it can act as",
+				Principal:   principal,
+				Role:        tokenCreatorRole + " + " + accountUserRole,
+				Mitigation:  "Remove one of the two roles, or scope them to disjoint sets of service accounts",
+			})
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,79 @@
+package iam
+
+// ReportDiff summarizes what changed between a Report and its Baseline:
+// issues newly present, issues that have since been resolved, and role
+// assignments added or removed.
+type ReportDiff struct {
+	NewIssues              []Issue          `json:"new_issues,omitempty"`
+	ResolvedIssues         []Issue          `json:"resolved_issues,omitempty"`
+	NewRoleAssignments     []RoleAssignment `json:"new_role_assignments,omitempty"`
+	RemovedRoleAssignments []RoleAssignment `json:"removed_role_assignments,omitempty"`
+}
+
+// issueKey identifies an Issue across runs by the check that raised it
+// plus its principal, role and description, since a freshly re-analyzed Issue
+// won't have a stable ID of its own to compare against.
+func issueKey(i Issue) string {
+	return i.CheckName + "|" + i.Principal + "|" + i.Role + "|" + i.Description
+}
+
+func roleAssignmentKey(ra RoleAssignment) string {
+	return ra.Principal + "|" + ra.Role + "|" + ra.Scope
+}
+
+// diffIssues returns the issues in a that aren't present in b, by issueKey.
+func diffIssues(a, b []Issue) []Issue {
+	seen := make(map[string]bool, len(b))
+	for _, issue := range b {
+		seen[issueKey(issue)] = true
+	}
+
+	var added []Issue
+	for _, issue := range a {
+		if !seen[issueKey(issue)] {
+			added = append(added, issue)
+		}
+	}
+	return added
+}
+
+// diffRoleAssignments returns the role assignments in a that aren't
+// present in b, by roleAssignmentKey.
+func diffRoleAssignments(a, b []RoleAssignment) []RoleAssignment {
+	seen := make(map[string]bool, len(b))
+	for _, ra := range b {
+		seen[roleAssignmentKey(ra)] = true
+	}
+
+	var added []RoleAssignment
+	for _, ra := range a {
+		if !seen[roleAssignmentKey(ra)] {
+			added = append(added, ra)
+		}
+	}
+	return added
+}
+
+// DiffSinceBaseline compares report against report.Baseline and returns
+// what changed. It returns nil if report.Baseline is unset.
+func (r *Report) DiffSinceBaseline() *ReportDiff {
+	if r.Baseline == nil {
+		return nil
+	}
+	return DiffReports(r.Baseline, r)
+}
+
+// DiffReports compares two reports for the same project and returns what
+// changed between them: issues added or resolved and role assignments
+// granted or removed going from old to new. Unlike DiffSinceBaseline,
+// neither report needs its Baseline field populated, which is what lets
+// the archive subsystem diff two arbitrary historical runs instead of
+// only "current vs. most recent".
+func DiffReports(old, new *Report) *ReportDiff {
+	return &ReportDiff{
+		NewIssues:              diffIssues(new.Issues, old.Issues),
+		ResolvedIssues:         diffIssues(old.Issues, new.Issues),
+		NewRoleAssignments:     diffRoleAssignments(new.RoleAssignments, old.RoleAssignments),
+		RemovedRoleAssignments: diffRoleAssignments(old.RoleAssignments, new.RoleAssignments),
+	}
+}
@@ -0,0 +1,192 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	"cloud.google.com/go/asset/apiv1/assetpb"
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iterator"
+)
+
+// PolicyBinding is a single principal/role/scope binding discovered by a
+// PolicyFetcher. It is the common currency between fetchers and the
+// Analyzer so checks don't need to know whether the data came from Cloud
+// Asset Inventory, a fixture, or a cache.
+type PolicyBinding struct {
+	Principal string
+	Role      string
+	Scope     string
+	Resource  string
+	Etag      string
+}
+
+// ServiceAccountKey describes a single key belonging to a service account.
+type ServiceAccountKey struct {
+	Name     string
+	KeyType  string
+	ValidAfter string
+}
+
+// PolicyFetcher abstracts how IAM policy data is retrieved so the Analyzer
+// can run against real GCP APIs in production and against fixtures in
+// tests.
+type PolicyFetcher interface {
+	// SearchAllIamPolicies returns every IAM policy binding visible to the
+	// caller under scope (an organization, folder, or project resource
+	// name), handling pagination internally.
+	SearchAllIamPolicies(ctx context.Context, scope string) ([]PolicyBinding, error)
+
+	// ListServiceAccounts returns the service accounts in a project.
+	ListServiceAccounts(ctx context.Context, projectID string) ([]*iam.ServiceAccount, error)
+
+	// ListServiceAccountKeys returns the keys for a single service account.
+	ListServiceAccountKeys(ctx context.Context, serviceAccountResource string) ([]ServiceAccountKey, error)
+}
+
+// CloudAssetFetcher implements PolicyFetcher against the real Cloud Asset
+// Inventory and IAM Admin APIs.
+type CloudAssetFetcher struct {
+	assetClient *asset.Client
+	iamService  *iam.Service
+}
+
+// NewCloudAssetFetcher creates a PolicyFetcher backed by Cloud Asset
+// Inventory (for policy search) and the IAM Admin API (for service
+// accounts and keys).
+func NewCloudAssetFetcher(ctx context.Context, iamService *iam.Service) (*CloudAssetFetcher, error) {
+	assetClient, err := asset.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud asset client: %w", err)
+	}
+
+	return &CloudAssetFetcher{
+		assetClient: assetClient,
+		iamService:  iamService,
+	}, nil
+}
+
+// SearchAllIamPolicies paginates through Cloud Asset Inventory's
+// SearchAllIamPolicies RPC and flattens each policy's bindings into
+// PolicyBindings.
+func (f *CloudAssetFetcher) SearchAllIamPolicies(ctx context.Context, scope string) (_ []PolicyBinding, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "SearchAllIamPolicies", start, err) }()
+
+	var bindings []PolicyBinding
+
+	it := f.assetClient.SearchAllIamPolicies(ctx, &assetpb.SearchAllIamPoliciesRequest{
+		Scope: scope,
+	})
+
+	for {
+		result, itErr := it.Next()
+		if itErr == iterator.Done {
+			break
+		}
+		if itErr != nil {
+			err = fmt.Errorf("failed to search IAM policies: %w", itErr)
+			return nil, err
+		}
+
+		if result.Policy == nil {
+			continue
+		}
+
+		etag := policyEtag(result.Policy)
+		for _, binding := range result.Policy.Bindings {
+			for _, member := range binding.Members {
+				bindings = append(bindings, PolicyBinding{
+					Principal: member,
+					Role:      binding.Role,
+					Scope:     scope,
+					Resource:  result.Resource,
+					Etag:      etag,
+				})
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
+// policyEtag derives a content hash from policy's bindings, standing in
+// for a server-provided etag: Cloud Asset Inventory's
+// IamPolicySearchResult doesn't expose one, only the policy body itself.
+// Hashing role+member pairs (sorted, so binding order doesn't matter) is
+// enough for DiskCache's purposes -- detecting whether a resource's
+// policy changed since the last run.
+func policyEtag(policy *iampb.Policy) string {
+	roles := make([]string, 0, len(policy.GetBindings()))
+	membersByRole := make(map[string][]string, len(policy.GetBindings()))
+	for _, binding := range policy.GetBindings() {
+		members := append([]string(nil), binding.Members...)
+		sort.Strings(members)
+		membersByRole[binding.Role] = members
+		roles = append(roles, binding.Role)
+	}
+	sort.Strings(roles)
+
+	h := sha256.New()
+	for _, role := range roles {
+		h.Write([]byte(role))
+		for _, member := range membersByRole[role] {
+			h.Write([]byte(member))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ListServiceAccounts paginates through Projects.ServiceAccounts.List.
+func (f *CloudAssetFetcher) ListServiceAccounts(ctx context.Context, projectID string) (_ []*iam.ServiceAccount, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListServiceAccounts", start, err) }()
+
+	var accounts []*iam.ServiceAccount
+
+	name := "projects/" + projectID
+	call := f.iamService.Projects.ServiceAccounts.List(name)
+	err = call.Pages(ctx, func(page *iam.ListServiceAccountsResponse) error {
+		accounts = append(accounts, page.Accounts...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// ListServiceAccountKeys calls Projects.ServiceAccounts.Keys.List for a
+// single service account resource (e.g. "projects/p/serviceAccounts/sa@p.iam.gserviceaccount.com").
+func (f *CloudAssetFetcher) ListServiceAccountKeys(ctx context.Context, serviceAccountResource string) (_ []ServiceAccountKey, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListServiceAccountKeys", start, err) }()
+
+	resp, err := f.iamService.Projects.ServiceAccounts.Keys.List(serviceAccountResource).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service account keys for %s: %w", serviceAccountResource, err)
+	}
+
+	keys := make([]ServiceAccountKey, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		keys = append(keys, ServiceAccountKey{
+			Name:       key.Name,
+			KeyType:    key.KeyType,
+			ValidAfter: key.ValidAfterTime,
+		})
+	}
+
+	return keys, nil
+}
+
+// Close releases the underlying Cloud Asset client.
+func (f *CloudAssetFetcher) Close() error {
+	return f.assetClient.Close()
+}
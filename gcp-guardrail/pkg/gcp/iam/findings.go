@@ -0,0 +1,20 @@
+package iam
+
+import "github.com/hieptle/gcp-guardrail/pkg/report"
+
+// ToFindings converts a Report's Issues into the shared report.Finding
+// shape, so iam-analyzer can hand its results to pkg/report's GitHub
+// Actions reporter alongside its own SARIF/JUnit/JSON/CSV writers.
+func ToFindings(r *Report) []report.Finding {
+	findings := make([]report.Finding, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		findings = append(findings, report.Finding{
+			RuleID:      issue.CheckName,
+			Severity:    issue.Severity,
+			Resource:    issue.Principal,
+			Message:     issue.Description,
+			Remediation: issue.Mitigation,
+		})
+	}
+	return findings
+}
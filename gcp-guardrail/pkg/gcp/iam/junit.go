@@ -0,0 +1,86 @@
+package iam
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitTestSuites is the JUnit XML root element. One <testcase> is emitted
+// per Check that raised at least one Issue (checks with no findings are
+// not listed), with one <failure> per Issue it raised so CI systems can
+// fail the build on regressions.
+type junitTestSuites struct {
+	XMLName  xml.Name        `xml:"testsuites"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string         `xml:"name,attr"`
+	Tests    int            `xml:"tests,attr"`
+	Failures int            `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name     string          `xml:"name,attr"`
+	Failures []junitFailure  `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the report as JUnit XML, grouping issues by the
+// check that raised them into one <testcase> per check.
+func writeJUnitReport(w io.Writer, report *Report) error {
+	byCheck := make(map[string][]Issue)
+	var order []string
+	for _, issue := range report.Issues {
+		name := issue.CheckName
+		if name == "" {
+			name = "unclassified"
+		}
+		if _, seen := byCheck[name]; !seen {
+			order = append(order, name)
+		}
+		byCheck[name] = append(byCheck[name], issue)
+	}
+
+	suite := junitTestSuite{
+		Name: fmt.Sprintf("iam-analyzer:%s", report.ProjectID),
+	}
+
+	for _, name := range order {
+		issues := byCheck[name]
+		testCase := junitTestCase{Name: name}
+		for _, issue := range issues {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: fmt.Sprintf("[%s] %s", issue.Severity, issue.Description),
+				Text:    fmt.Sprintf("Principal: %s\nRole: %s\nMitigation: %s", issue.Principal, issue.Role, issue.Mitigation),
+			})
+		}
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suite.Failures += len(testCase.Failures)
+	}
+
+	doc := junitTestSuites{
+		Name:     "iam-analyzer",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := w.Write([]byte("\n"))
+	return err
+}
@@ -0,0 +1,142 @@
+package iam
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rolePermissionsJSON is a role-to-permission expansion table embedded at
+// compile time, similar to the scope tables trufflehog embeds for its
+// analyzers. It is generated offline from `gcloud iam roles describe
+// <role> --format=json` output; see roles.json in this package.
+//
+//go:embed roles.json
+var rolePermissionsJSON []byte
+
+// rolePermissions is the parsed form of rolePermissionsJSON, built once at
+// package init.
+var rolePermissions map[string][]string
+
+func init() {
+	if err := json.Unmarshal(rolePermissionsJSON, &rolePermissions); err != nil {
+		panic(fmt.Sprintf("iam: failed to parse embedded roles.json: %v", err))
+	}
+}
+
+// ExpandRole returns the permissions granted by role, or nil if the role
+// isn't in the embedded expansion table.
+func ExpandRole(role string) []string {
+	return rolePermissions[role]
+}
+
+// EffectivePermissions returns the union of permissions granted by every
+// role in roles, deduplicated.
+func EffectivePermissions(roles []string) []string {
+	seen := make(map[string]bool)
+	var effective []string
+	for _, role := range roles {
+		for _, permission := range ExpandRole(role) {
+			if seen[permission] {
+				continue
+			}
+			seen[permission] = true
+			effective = append(effective, permission)
+		}
+	}
+	return effective
+}
+
+// PermissionDiff reports the gap between the permissions a principal
+// effectively holds and the permissions it has actually used, as observed
+// in Cloud Audit Logs.
+type PermissionDiff struct {
+	Principal            string
+	EffectivePermissions []string
+	UsedPermissions      []string
+	UnusedPermissions    []string
+}
+
+// DiffPermissions computes the permissions in effective that don't appear
+// in used, i.e. permissions the principal holds but never exercised.
+func DiffPermissions(principal string, effective, used []string) PermissionDiff {
+	usedSet := make(map[string]bool, len(used))
+	for _, p := range used {
+		usedSet[p] = true
+	}
+
+	var unused []string
+	for _, p := range effective {
+		if p == "*" {
+			// A wildcard permission (e.g. roles/owner) can't meaningfully
+			// be diffed against an audit log of concrete permissions.
+			continue
+		}
+		if !usedSet[p] {
+			unused = append(unused, p)
+		}
+	}
+
+	return PermissionDiff{
+		Principal:            principal,
+		EffectivePermissions: effective,
+		UsedPermissions:      used,
+		UnusedPermissions:    unused,
+	}
+}
+
+// RecommendRole suggests the smallest predefined role (from the embedded
+// roles.json table) whose expanded permission set is a superset of used,
+// for least-privilege role recommendations. It returns "" if no
+// predefined role covers every used permission.
+func RecommendRole(used []string) string {
+	usedSet := make(map[string]bool, len(used))
+	for _, p := range used {
+		usedSet[p] = true
+	}
+
+	var candidates []string
+	for role, permissions := range rolePermissions {
+		if coversAll(permissions, usedSet) {
+			candidates = append(candidates, role)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return len(rolePermissions[candidates[i]]) < len(rolePermissions[candidates[j]])
+	})
+	return candidates[0]
+}
+
+// coversAll reports whether every permission in used is present in
+// permissions, treating a trailing "*" segment as covering everything
+// under that prefix (e.g. "resourcemanager.projects.*" covers
+// "resourcemanager.projects.get").
+func coversAll(permissions []string, used map[string]bool) bool {
+	if len(used) == 0 {
+		return false
+	}
+
+	for permission := range used {
+		covered := false
+		for _, granted := range permissions {
+			if granted == "*" || granted == permission {
+				covered = true
+				break
+			}
+			if strings.HasSuffix(granted, ".*") && strings.HasPrefix(permission, strings.TrimSuffix(granted, "*")) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
@@ -19,8 +19,30 @@ const (
 	JSONFormat ReportFormat = "json"
 	// CSVFormat outputs the report in CSV format
 	CSVFormat ReportFormat = "csv"
+	// NDJSONFormat outputs the report as newline-delimited JSON, one
+	// record per role assignment/issue/unused permission; only supported
+	// by StreamingReportWriter, since a batch Report has no line-oriented
+	// representation to convert from.
+	NDJSONFormat ReportFormat = "ndjson"
+	// SARIFFormat outputs the report as SARIF 2.1.0 for GitHub Code Scanning and similar tools
+	SARIFFormat ReportFormat = "sarif"
+	// JUnitFormat outputs the report as JUnit XML so CI pipelines can fail on regressions
+	JUnitFormat ReportFormat = "junit"
 )
 
+// Stats summarizes an IAM analysis report's contents, shared between the
+// batch Report type and StreamingReportWriter's WriteFooter.
+type Stats struct {
+	TotalIssues       int `json:"total_issues"`
+	CriticalIssues    int `json:"critical_issues"`
+	HighIssues        int `json:"high_issues"`
+	MediumIssues      int `json:"medium_issues"`
+	LowIssues         int `json:"low_issues"`
+	TotalRoles        int `json:"total_roles"`
+	TotalPrincipals   int `json:"total_principals"`
+	UnusedPermissions int `json:"unused_permissions"`
+}
+
 // Report represents an IAM analysis report
 type Report struct {
 	ProjectID         string             `json:"project_id"`
@@ -28,16 +50,13 @@ type Report struct {
 	RoleAssignments   []RoleAssignment   `json:"role_assignments,omitempty"`
 	Issues            []Issue            `json:"issues"`
 	UnusedPermissions []UnusedPermission `json:"unused_permissions,omitempty"`
-	Stats             struct {
-		TotalIssues       int `json:"total_issues"`
-		CriticalIssues    int `json:"critical_issues"`
-		HighIssues        int `json:"high_issues"`
-		MediumIssues      int `json:"medium_issues"`
-		LowIssues         int `json:"low_issues"`
-		TotalRoles        int `json:"total_roles"`
-		TotalPrincipals   int `json:"total_principals"`
-		UnusedPermissions int `json:"unused_permissions"`
-	} `json:"stats"`
+	// Baseline is the most recent prior report for this project, set by
+	// callers that want the text/JSON/CSV writers to render a "new since
+	// last run" section (see DiffSinceBaseline). It's excluded from JSON
+	// output itself to avoid nesting the entire prior report; a computed
+	// diff is emitted in its place.
+	Baseline *Report `json:"-"`
+	Stats    Stats   `json:"stats"`
 }
 
 // NewReport creates a new IAM analysis report from an Analysis result
@@ -79,6 +98,14 @@ func NewReport(analysis *Analysis) *Report {
 	return report
 }
 
+// reportWithDiff wraps a Report for JSON output when it carries a
+// Baseline, adding the computed diff alongside it rather than nesting
+// the entire prior report.
+type reportWithDiff struct {
+	*Report
+	NewSinceLastRun *ReportDiff `json:"new_since_last_run,omitempty"`
+}
+
 // WriteReport writes the report to the specified writer in the specified format
 func WriteReport(w io.Writer, report *Report, format ReportFormat) error {
 	switch format {
@@ -88,6 +115,10 @@ func WriteReport(w io.Writer, report *Report, format ReportFormat) error {
 		return writeJSONReport(w, report)
 	case CSVFormat:
 		return writeCSVReport(w, report)
+	case SARIFFormat:
+		return writeSARIFReport(w, report)
+	case JUnitFormat:
+		return writeJUnitReport(w, report)
 	default:
 		return fmt.Errorf("unsupported report format: %s", format)
 	}
@@ -150,13 +181,35 @@ func writeTextReport(w io.Writer, report *Report) error {
 		}
 	}
 
+	// Write drift against the baseline, if one was attached.
+	if report.Baseline != nil {
+		diff := report.DiffSinceBaseline()
+		fmt.Fprintf(w, "## NEW SINCE LAST RUN (baseline: %s)\n", report.Baseline.GeneratedAt.Format(time.RFC1123))
+		fmt.Fprintf(w, "New issues: %d\n", len(diff.NewIssues))
+		for i, issue := range diff.NewIssues {
+			fmt.Fprintf(w, "%d. [%s] %s (%s / %s)\n", i+1, issue.Severity, issue.Description, issue.Principal, issue.Role)
+		}
+		fmt.Fprintf(w, "Resolved issues: %d\n", len(diff.ResolvedIssues))
+		for i, issue := range diff.ResolvedIssues {
+			fmt.Fprintf(w, "%d. [%s] %s (%s / %s)\n", i+1, issue.Severity, issue.Description, issue.Principal, issue.Role)
+		}
+		fmt.Fprintf(w, "New role assignments: %d\n", len(diff.NewRoleAssignments))
+		fmt.Fprintf(w, "Removed role assignments: %d\n\n", len(diff.RemovedRoleAssignments))
+	}
+
 	return nil
 }
 
-// writeJSONReport writes the report in JSON format
+// writeJSONReport writes the report in JSON format. If the report carries
+// a Baseline, the computed diff is emitted alongside it under
+// new_since_last_run rather than nesting the entire prior report.
 func writeJSONReport(w io.Writer, report *Report) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
+
+	if report.Baseline != nil {
+		return encoder.Encode(reportWithDiff{Report: report, NewSinceLastRun: report.DiffSinceBaseline()})
+	}
 	return encoder.Encode(report)
 }
 
@@ -188,7 +241,34 @@ func writeCSVReport(w io.Writer, report *Report) error {
 	}
 	
 	csvWriter.Flush()
-	return csvWriter.Error()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+
+	// Append a "new since last run" section, if a baseline was attached.
+	if report.Baseline != nil {
+		diff := report.DiffSinceBaseline()
+		fmt.Fprintf(w, "\nNew Since Last Run (baseline: %s)\n", report.Baseline.GeneratedAt.Format(time.RFC3339))
+
+		diffWriter := csv.NewWriter(w)
+		if err := diffWriter.Write([]string{"Status", "Severity", "Description", "Principal", "Role", "Mitigation"}); err != nil {
+			return err
+		}
+		for _, issue := range diff.NewIssues {
+			if err := diffWriter.Write([]string{"NEW", issue.Severity, issue.Description, issue.Principal, issue.Role, issue.Mitigation}); err != nil {
+				return err
+			}
+		}
+		for _, issue := range diff.ResolvedIssues {
+			if err := diffWriter.Write([]string{"RESOLVED", issue.Severity, issue.Description, issue.Principal, issue.Role, issue.Mitigation}); err != nil {
+				return err
+			}
+		}
+		diffWriter.Flush()
+		return diffWriter.Error()
+	}
+
+	return nil
 }
 
 // formatLastUsed formats the last used time for display
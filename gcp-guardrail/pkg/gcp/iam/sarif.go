@@ -0,0 +1,138 @@
+package iam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifLog is the root SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name            string      `json:"name"`
+	InformationURI  string      `json:"informationUri,omitempty"`
+	Rules           []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevel maps an Issue severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifFingerprint derives a stable fingerprint for an issue so GitHub Code
+// Scanning can deduplicate it across runs even as line-level detail changes.
+func sarifFingerprint(ruleID string, issue Issue) string {
+	sum := sha256.Sum256([]byte(ruleID + "|" + issue.Principal + "|" + issue.Role))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeSARIFReport writes the report as SARIF 2.1.0 JSON. Each Issue
+// becomes one result; the rule id comes from sarifRuleID (the issue's
+// CheckName).
+func writeSARIFReport(w io.Writer, report *Report) error {
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, issue := range report.Issues {
+		ruleID := sarifRuleID(issue)
+		if !ruleIDs[ruleID] {
+			ruleIDs[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID, Name: ruleID})
+		}
+
+		location := fmt.Sprintf("%s/%s", issue.Principal, issue.Role)
+		results = append(results, sarifResult{
+			RuleID: ruleID,
+			Level:  sarifLevel(issue.Severity),
+			Message: sarifMessage{
+				Text: issue.Description,
+			},
+			Locations: []sarifLocation{
+				{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: location}}},
+			},
+			PartialFingerprints: map[string]string{
+				"primaryLocationLineHash": sarifFingerprint(ruleID, issue),
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "gcpgolang-iam-analyzer",
+						InformationURI: "https://github.com/hieptle/gcp-guardrail",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifRuleID returns the check name that produced issue, falling back to
+// the severity for issues that didn't go through the Check registry (e.g.
+// hand-built fixtures in tests).
+func sarifRuleID(issue Issue) string {
+	if issue.CheckName != "" {
+		return issue.CheckName
+	}
+	return "iam/" + issue.Severity
+}
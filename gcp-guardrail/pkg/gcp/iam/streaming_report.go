@@ -0,0 +1,312 @@
+package iam
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReportMeta is the header information a StreamingReportWriter needs
+// before any Issue/RoleAssignment/UnusedPermission arrives.
+type ReportMeta struct {
+	ProjectID   string    `json:"project_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// StreamingReportWriter incrementally emits a report's sections as the
+// Analyzer discovers them, so an org-wide analysis with millions of role
+// bindings never needs the whole Analysis materialized in memory at
+// once. Callers must call WriteHeader first, then WriteRoleAssignment
+// for every role assignment (if any), then WriteIssue for every issue,
+// then WriteUnusedPermission for every unused permission (if any), and
+// finally WriteFooter exactly once; implementations rely on this order
+// to know when one section ends and the next begins.
+type StreamingReportWriter interface {
+	WriteHeader(meta ReportMeta) error
+	WriteRoleAssignment(ra RoleAssignment) error
+	WriteIssue(issue Issue) error
+	WriteUnusedPermission(up UnusedPermission) error
+	WriteFooter(stats Stats) error
+}
+
+// NewStreamingReportWriter returns the StreamingReportWriter for format,
+// or an error if format has no streaming implementation (currently
+// SARIF and JUnit require the whole report, since their schemas don't
+// lend themselves to incremental framing).
+func NewStreamingReportWriter(w io.Writer, format ReportFormat) (StreamingReportWriter, error) {
+	switch format {
+	case TextFormat:
+		return newTextStreamWriter(w), nil
+	case JSONFormat:
+		return newJSONStreamWriter(w), nil
+	case CSVFormat:
+		return newCSVStreamWriter(w), nil
+	case NDJSONFormat:
+		return newNDJSONStreamWriter(w), nil
+	default:
+		return nil, fmt.Errorf("no streaming writer for report format: %s", format)
+	}
+}
+
+// textStreamWriter renders the same sections as writeTextReport, but
+// issue/role-assignment/unused-permission lines are written as they
+// arrive instead of ranging over an in-memory slice. Totals in the
+// summary are only known at WriteFooter, so the summary is printed last
+// rather than first, unlike the batch text report.
+type textStreamWriter struct {
+	w              io.Writer
+	issueCount     int
+	roleCount      int
+	unusedCount    int
+	wroteIssuesHdr bool
+	wroteRolesHdr  bool
+	wroteUnusedHdr bool
+}
+
+func newTextStreamWriter(w io.Writer) *textStreamWriter {
+	return &textStreamWriter{w: w}
+}
+
+func (t *textStreamWriter) WriteHeader(meta ReportMeta) error {
+	_, err := fmt.Fprintf(t.w, "# IAM POLICY ANALYSIS REPORT\nProject: %s\nGenerated: %s\n\n",
+		meta.ProjectID, meta.GeneratedAt.Format(time.RFC1123))
+	return err
+}
+
+func (t *textStreamWriter) WriteRoleAssignment(ra RoleAssignment) error {
+	if !t.wroteRolesHdr {
+		if _, err := fmt.Fprintf(t.w, "## ROLE ASSIGNMENTS\n"); err != nil {
+			return err
+		}
+		t.wroteRolesHdr = true
+	}
+	t.roleCount++
+	if _, err := fmt.Fprintf(t.w, "%d. Principal: %s\n   Role: %s\n   Scope: %s\n", t.roleCount, ra.Principal, ra.Role, ra.Scope); err != nil {
+		return err
+	}
+	for _, access := range ra.EffectiveAccess {
+		if _, err := fmt.Fprintf(t.w, "     - %s\n", access); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(t.w, "\n")
+	return err
+}
+
+func (t *textStreamWriter) WriteIssue(issue Issue) error {
+	if !t.wroteIssuesHdr {
+		if _, err := fmt.Fprintf(t.w, "## SECURITY ISSUES\n"); err != nil {
+			return err
+		}
+		t.wroteIssuesHdr = true
+	}
+	t.issueCount++
+	_, err := fmt.Fprintf(t.w, "%d. [%s] %s\n   Principal: %s\n   Role: %s\n   Mitigation: %s\n\n",
+		t.issueCount, issue.Severity, issue.Description, issue.Principal, issue.Role, issue.Mitigation)
+	return err
+}
+
+func (t *textStreamWriter) WriteUnusedPermission(up UnusedPermission) error {
+	if !t.wroteUnusedHdr {
+		if _, err := fmt.Fprintf(t.w, "## UNUSED PERMISSIONS\n"); err != nil {
+			return err
+		}
+		t.wroteUnusedHdr = true
+	}
+	t.unusedCount++
+	_, err := fmt.Fprintf(t.w, "%d. Principal: %s\n   Role: %s\n   Permission: %s\n   Last Used: %s\n   Recommended: %s\n\n",
+		t.unusedCount, up.Principal, up.Role, up.Permission, formatLastUsed(up.LastUsed), up.Recommended)
+	return err
+}
+
+func (t *textStreamWriter) WriteFooter(stats Stats) error {
+	_, err := fmt.Fprintf(t.w, "## SUMMARY\nTotal issues: %d\n  Critical: %d\n  High: %d\n  Medium: %d\n  Low: %d\nTotal roles analyzed: %d\nTotal principals: %d\nUnused permissions: %d\n",
+		stats.TotalIssues, stats.CriticalIssues, stats.HighIssues, stats.MediumIssues, stats.LowIssues,
+		stats.TotalRoles, stats.TotalPrincipals, stats.UnusedPermissions)
+	return err
+}
+
+// jsonStreamWriter emits the same fields as the batch Report type, but
+// frames each array with manually-written "["/"]" so encoding/json never
+// needs the full slice: each element is encoded individually as it
+// arrives and separated by a hand-written comma.
+type jsonStreamWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	section string // "", "role_assignments", "issues", or "unused_permissions"
+	first   bool
+}
+
+func newJSONStreamWriter(w io.Writer) *jsonStreamWriter {
+	return &jsonStreamWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+func (j *jsonStreamWriter) WriteHeader(meta ReportMeta) error {
+	projectID, err := json.Marshal(meta.ProjectID)
+	if err != nil {
+		return err
+	}
+	generatedAt, err := json.Marshal(meta.GeneratedAt)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, `{"project_id":%s,"generated_at":%s,"role_assignments":[`, projectID, generatedAt)
+	j.section = "role_assignments"
+	j.first = true
+	return err
+}
+
+// advance closes the currently open array and opens the next section's,
+// if we aren't already in it.
+func (j *jsonStreamWriter) advance(to string) error {
+	if j.section == to {
+		return nil
+	}
+	if _, err := fmt.Fprintf(j.w, "],%q:[", to); err != nil {
+		return err
+	}
+	j.section = to
+	j.first = true
+	return nil
+}
+
+func (j *jsonStreamWriter) writeItem(v interface{}) error {
+	if !j.first {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.first = false
+	return j.enc.Encode(v)
+}
+
+func (j *jsonStreamWriter) WriteRoleAssignment(ra RoleAssignment) error {
+	if err := j.advance("role_assignments"); err != nil {
+		return err
+	}
+	return j.writeItem(ra)
+}
+
+func (j *jsonStreamWriter) WriteIssue(issue Issue) error {
+	if err := j.advance("issues"); err != nil {
+		return err
+	}
+	return j.writeItem(issue)
+}
+
+func (j *jsonStreamWriter) WriteUnusedPermission(up UnusedPermission) error {
+	// As in WriteFooter, emit the issues key even when no issue was written.
+	if j.section == "role_assignments" {
+		if err := j.advance("issues"); err != nil {
+			return err
+		}
+	}
+	if err := j.advance("unused_permissions"); err != nil {
+		return err
+	}
+	return j.writeItem(up)
+}
+
+func (j *jsonStreamWriter) WriteFooter(stats Stats) error {
+	// Issues has no `omitempty` on the batch Report type, so make sure
+	// its key is always present even when zero issues were ever written.
+	if j.section == "role_assignments" {
+		if err := j.advance("issues"); err != nil {
+			return err
+		}
+	}
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, `],"stats":%s}`, statsJSON)
+	return err
+}
+
+// csvStreamWriter mirrors writeCSVReport, which only ever emits the
+// issues table; role assignments and unused permissions have no CSV
+// columns defined for them and are silently accepted as no-ops here too.
+type csvStreamWriter struct {
+	w        *csv.Writer
+	wroteHdr bool
+}
+
+func newCSVStreamWriter(w io.Writer) *csvStreamWriter {
+	return &csvStreamWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvStreamWriter) WriteHeader(meta ReportMeta) error {
+	return nil
+}
+
+func (c *csvStreamWriter) WriteRoleAssignment(ra RoleAssignment) error {
+	return nil
+}
+
+func (c *csvStreamWriter) WriteIssue(issue Issue) error {
+	if !c.wroteHdr {
+		if err := c.w.Write([]string{"Severity", "Description", "Principal", "Role", "Mitigation"}); err != nil {
+			return err
+		}
+		c.wroteHdr = true
+	}
+	return c.w.Write([]string{issue.Severity, issue.Description, issue.Principal, issue.Role, issue.Mitigation})
+}
+
+func (c *csvStreamWriter) WriteUnusedPermission(up UnusedPermission) error {
+	return nil
+}
+
+func (c *csvStreamWriter) WriteFooter(stats Stats) error {
+	if !c.wroteHdr {
+		if err := c.w.Write([]string{"Severity", "Description", "Principal", "Role", "Mitigation"}); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonStreamWriter emits one JSON object per line, prefixed with a
+// "type" discriminator ("meta", "role_assignment", "issue",
+// "unused_permission", "stats"), so a consumer can process the report as
+// it streams in without ever buffering the whole thing.
+type ndjsonStreamWriter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONStreamWriter(w io.Writer) *ndjsonStreamWriter {
+	return &ndjsonStreamWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+func (n *ndjsonStreamWriter) writeLine(recordType string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(n.w, `{"type":%q,"data":%s}`+"\n", recordType, data)
+	return err
+}
+
+func (n *ndjsonStreamWriter) WriteHeader(meta ReportMeta) error {
+	return n.writeLine("meta", meta)
+}
+
+func (n *ndjsonStreamWriter) WriteRoleAssignment(ra RoleAssignment) error {
+	return n.writeLine("role_assignment", ra)
+}
+
+func (n *ndjsonStreamWriter) WriteIssue(issue Issue) error {
+	return n.writeLine("issue", issue)
+}
+
+func (n *ndjsonStreamWriter) WriteUnusedPermission(up UnusedPermission) error {
+	return n.writeLine("unused_permission", up)
+}
+
+func (n *ndjsonStreamWriter) WriteFooter(stats Stats) error {
+	return n.writeLine("stats", stats)
+}
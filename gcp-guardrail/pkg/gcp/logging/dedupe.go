@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// dedupeCache is a fixed-capacity, in-memory LRU of recently-fired dedupe
+// keys. Seen reports whether key fired within the last ttl and, if not,
+// records it as fired now. It exists so Tail can throttle repeated alerts
+// for the same resource without needing external state.
+type dedupeCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type dedupeEntry struct {
+	key     string
+	firedAt time.Time
+}
+
+func newDedupeCache(capacity int) *dedupeCache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &dedupeCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Seen returns true if key was already recorded within ttl, throttling the
+// caller. Otherwise it records key as fired at now and returns false.
+func (c *dedupeCache) Seen(key string, ttl time.Duration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*dedupeEntry)
+		if now.Sub(entry.firedAt) < ttl {
+			c.ll.MoveToFront(el)
+			return true
+		}
+		entry.firedAt = now
+		c.ll.MoveToFront(el)
+		return false
+	}
+
+	el := c.ll.PushFront(&dedupeEntry{key: key, firedAt: now})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*dedupeEntry).key)
+		}
+	}
+
+	return false
+}
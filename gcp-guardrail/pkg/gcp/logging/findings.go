@@ -0,0 +1,19 @@
+package logging
+
+import "github.com/hieptle/gcp-guardrail/pkg/report"
+
+// ToFindings converts a batch of Alerts into the shared report.Finding
+// shape, so log-watcher's one-shot scan can hand its results to
+// pkg/report's writers.
+func ToFindings(alerts []*Alert) []report.Finding {
+	findings := make([]report.Finding, 0, len(alerts))
+	for _, a := range alerts {
+		findings = append(findings, report.Finding{
+			RuleID:   a.LogName,
+			Severity: a.Severity,
+			Resource: a.Resource,
+			Message:  a.Description,
+		})
+	}
+	return findings
+}
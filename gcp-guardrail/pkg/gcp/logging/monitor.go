@@ -8,9 +8,64 @@ import (
 
 	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"cloud.google.com/go/pubsub"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/api/logging/v2"
 )
 
+// tracer and meter instrument QueryLogs (and Tail's polling loop) so
+// scheduled/long-running invocations can be observed for API cost and
+// error rates via OTLP.
+var (
+	tracer = otel.Tracer("github.com/hieptle/gcp-guardrail/pkg/gcp/logging")
+	meter  = otel.Meter("github.com/hieptle/gcp-guardrail/pkg/gcp/logging")
+
+	issuesFoundCounter  metric.Int64Counter
+	apiCallsCounter     metric.Int64Counter
+	apiLatencyHistogram metric.Float64Histogram
+)
+
+func init() {
+	var err error
+	issuesFoundCounter, err = meter.Int64Counter(
+		"gcpgolang.logging.issues_found",
+		metric.WithDescription("Number of alerts raised from log analysis"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("logging: failed to register issues_found counter: %v", err))
+	}
+
+	apiCallsCounter, err = meter.Int64Counter(
+		"gcpgolang.logging.api_calls",
+		metric.WithDescription("Number of GCP API calls made by the log monitor, by method"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("logging: failed to register api_calls counter: %v", err))
+	}
+
+	apiLatencyHistogram, err = meter.Float64Histogram(
+		"gcpgolang.logging.api_latency",
+		metric.WithDescription("Latency of GCP API calls made by the log monitor, in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("logging: failed to register api_latency histogram: %v", err))
+	}
+}
+
+// recordAPICall records one API call's outcome and latency under the
+// api-calls and api-latency instruments, tagged with the method name and
+// whether it failed.
+func recordAPICall(ctx context.Context, method string, start time.Time, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.Bool("error", err != nil),
+	)
+	apiCallsCounter.Add(ctx, 1, attrs)
+	apiLatencyHistogram.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
 // Alert represents a security alert triggered from log analysis
 type Alert struct {
 	Timestamp   time.Time                 `json:"timestamp"`
@@ -30,6 +85,7 @@ type Monitor struct {
 	pubsubClient  *pubsub.Client
 	alertTopic    *pubsub.Topic
 	ctx           context.Context
+	dedupe        *dedupeCache
 }
 
 // NewMonitor creates a new GCP logging monitor
@@ -66,11 +122,18 @@ func NewMonitor(ctx context.Context, projectID string, alertTopicID string) (*Mo
 		pubsubClient:   pubsubClient,
 		alertTopic:     alertTopic,
 		ctx:            ctx,
+		dedupe:         newDedupeCache(4096),
 	}, nil
 }
 
 // QueryLogs queries logs and looks for security incidents
-func (m *Monitor) QueryLogs(filter string, timeWindow time.Duration) ([]*Alert, error) {
+func (m *Monitor) QueryLogs(filter string, timeWindow time.Duration) (_ []*Alert, err error) {
+	ctx, span := tracer.Start(m.ctx, "logging.QueryLogs")
+	defer span.End()
+
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListLogEntries", start, err) }()
+
 	endTime := time.Now()
 	startTime := endTime.Add(-timeWindow)
 
@@ -95,7 +158,7 @@ func (m *Monitor) QueryLogs(filter string, timeWindow time.Duration) ([]*Alert,
 
 	// Collect log entries and look for security incidents
 	var alerts []*Alert
-	err := listCall.Pages(m.ctx, func(page *logging.ListLogEntriesResponse) error {
+	err = listCall.Pages(ctx, func(page *logging.ListLogEntriesResponse) error {
 		for _, entry := range page.Entries {
 			// Process each log entry
 			// Here you would implement specific logic to detect security incidents
@@ -117,6 +180,8 @@ func (m *Monitor) QueryLogs(filter string, timeWindow time.Duration) ([]*Alert,
 		return nil, fmt.Errorf("failed to list logs: %w", err)
 	}
 
+	issuesFoundCounter.Add(ctx, int64(len(alerts)))
+
 	return alerts, nil
 }
 
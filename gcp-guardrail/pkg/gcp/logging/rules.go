@@ -0,0 +1,156 @@
+package logging
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"gopkg.in/yaml.v3"
+)
+
+// DetectionRule is a declarative description of a security pattern to watch
+// for in Cloud Logging entries. Rules are normally loaded from YAML (see
+// LoadRulesFile and the embedded starter pack in default_rules.yaml) rather
+// than constructed in code.
+type DetectionRule struct {
+	ID          string `yaml:"id" json:"id"`
+	Description string `yaml:"description" json:"description"`
+	Severity    string `yaml:"severity" json:"severity"`
+	// Filter is a Cloud Logging query used to narrow down which entries are
+	// fetched for this rule (combined with the monitor's time window).
+	Filter string `yaml:"filter" json:"filter"`
+	// Condition is a CEL expression evaluated against the entry; it must
+	// return a bool. The entry is exposed to the expression as `entry`,
+	// with fields matching the JSON representation of a Cloud Logging
+	// LogEntry (insertId, logName, resource, protoPayload, jsonPayload...).
+	Condition string `yaml:"condition" json:"condition"`
+	// Throttle is a duration string (e.g. "15m"). Once a rule fires for a
+	// given dedupe key, it won't fire again for the same key until
+	// Throttle has elapsed.
+	Throttle string `yaml:"throttle" json:"throttle"`
+	// DedupeKeyTemplate is a text/template string rendered against the
+	// entry to produce the key used for throttling; the key is the rule ID,
+	// a colon, and the rendered text. If empty, the rule ID is used as the
+	// template, so every entry of the rule shares one dedupe key.
+	DedupeKeyTemplate string `yaml:"dedupe_key_template" json:"dedupe_key_template"`
+
+	throttle time.Duration
+	program  cel.Program
+	dedupeTmpl *template.Template
+}
+
+//go:embed default_rules.yaml
+var defaultRulesYAML []byte
+
+// DefaultRules returns the starter detection rule pack shipped with
+// gcp-guardrail: public GCS bucket ACLs, newly-minted service account keys,
+// IAM grants of roles/owner to allUsers, and wide-open VPC firewall rules.
+func DefaultRules() ([]DetectionRule, error) {
+	return parseRules(defaultRulesYAML, ".yaml")
+}
+
+// LoadRulesFile loads detection rules from a YAML or JSON file, compiling
+// each rule's CEL condition and dedupe key template so Tail can apply them
+// without re-parsing on every entry.
+func LoadRulesFile(path string) ([]DetectionRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file %s: %w", path, err)
+	}
+	return parseRules(data, strings.ToLower(filepath.Ext(path)))
+}
+
+func parseRules(data []byte, ext string) ([]DetectionRule, error) {
+	var rules []DetectionRule
+	var err error
+	if ext == ".json" {
+		err = json.Unmarshal(data, &rules)
+	} else {
+		err = yaml.Unmarshal(data, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse detection rules: %w", err)
+	}
+
+	for i := range rules {
+		if err := rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rules[i].ID, err)
+		}
+	}
+	return rules, nil
+}
+
+// compile parses the rule's throttle duration, CEL condition, and dedupe
+// key template ahead of time so Tail's hot path only evaluates them.
+func (r *DetectionRule) compile() error {
+	if r.Throttle == "" {
+		r.throttle = 10 * time.Minute
+	} else {
+		d, err := time.ParseDuration(r.Throttle)
+		if err != nil {
+			return fmt.Errorf("invalid throttle %q: %w", r.Throttle, err)
+		}
+		r.throttle = d
+	}
+
+	env, err := cel.NewEnv(cel.Variable("entry", cel.DynType))
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(r.Condition)
+	if issues != nil && issues.Err() != nil {
+		return fmt.Errorf("failed to compile condition %q: %w", r.Condition, issues.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return fmt.Errorf("failed to build CEL program for condition %q: %w", r.Condition, err)
+	}
+	r.program = program
+
+	tmplSource := r.DedupeKeyTemplate
+	if tmplSource == "" {
+		tmplSource = r.ID
+	}
+	tmpl, err := template.New(r.ID).Parse(tmplSource)
+	if err != nil {
+		return fmt.Errorf("invalid dedupe_key_template %q: %w", r.DedupeKeyTemplate, err)
+	}
+	r.dedupeTmpl = tmpl
+
+	return nil
+}
+
+// ThrottleDuration returns the parsed throttle duration, defaulting to 10 minutes
+// if the rule didn't specify one.
+func (r *DetectionRule) ThrottleDuration() time.Duration {
+	return r.throttle
+}
+
+// matches evaluates the rule's CEL condition against entry, which must be
+// the JSON-decoded representation of a LogEntry.
+func (r *DetectionRule) matches(entry map[string]interface{}) (bool, error) {
+	out, _, err := r.program.Eval(map[string]interface{}{"entry": entry})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition for rule %s: %w", r.ID, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition for rule %s did not evaluate to a bool", r.ID)
+	}
+	return matched, nil
+}
+
+// dedupeKey renders the rule's dedupe key template against entry.
+func (r *DetectionRule) dedupeKey(entry map[string]interface{}) (string, error) {
+	var buf strings.Builder
+	if err := r.dedupeTmpl.Execute(&buf, entry); err != nil {
+		return "", fmt.Errorf("failed to render dedupe key for rule %s: %w", r.ID, err)
+	}
+	return r.ID + ":" + buf.String(), nil
+}
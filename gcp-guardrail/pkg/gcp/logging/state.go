@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// RuleState records where tailLoop left off for a single detection rule, so
+// a restart resumes polling from its last cursor instead of time.Now() and
+// can recognize the one entry (by InsertId) it already evaluated at the
+// previous cursor boundary.
+type RuleState struct {
+	Cursor       time.Time `json:"cursor"`
+	LastInsertID string    `json:"last_insert_id,omitempty"`
+}
+
+// TailState is the --state-file-persisted form of tailLoop's per-rule
+// cursors, keyed by DetectionRule.ID.
+type TailState struct {
+	Rules map[string]RuleState `json:"rules"`
+}
+
+// LoadTailState reads state from path, returning a fresh, empty TailState
+// (not an error) if the file doesn't exist yet, which is the normal case
+// for a rule set's very first run.
+func LoadTailState(path string) (*TailState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &TailState{Rules: make(map[string]RuleState)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %s: %w", path, err)
+	}
+
+	var state TailState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Rules == nil {
+		state.Rules = make(map[string]RuleState)
+	}
+	return &state, nil
+}
+
+// Save writes state to path as JSON, overwriting any existing file.
+func (s *TailState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tail state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	googlelogging "google.golang.org/api/logging/v2"
+)
+
+// defaultTailPollInterval is how often Tail re-queries Cloud Logging for
+// each rule when falling back to polling.
+const defaultTailPollInterval = 30 * time.Second
+
+// TailOptions configures Tail's optional state persistence and alert
+// delivery behavior.
+type TailOptions struct {
+	// StateFile, if set, persists each rule's last-seen cursor and
+	// InsertId to this path after every poll, and is loaded on startup so
+	// a restart resumes each rule from where it left off instead of from
+	// time.Now() (and doesn't re-alert on the entry already evaluated at
+	// the previous cursor boundary).
+	StateFile string
+	// DryRun, if true, prints alerts to stdout instead of publishing them
+	// via PublishAlert.
+	DryRun bool
+}
+
+// Tail evaluates rules against new log entries as they arrive and streams
+// matching alerts on the returned channel. It also delivers each
+// non-throttled alert via PublishAlert, or to stdout if opts.DryRun is set.
+//
+// The Logging v2 TailLogEntries RPC is a bidirectional gRPC stream and
+// isn't exposed by the REST client this package is built on
+// (google.golang.org/api/logging/v2), so Tail falls back to polling each
+// rule's filter on a fixed interval using a timestamp cursor. Swapping in
+// the gRPC streaming client (cloud.google.com/go/logging/apiv2) later would
+// let this same loop push entries instead of polling for them.
+//
+// The returned channel is closed when ctx is done.
+func (m *Monitor) Tail(ctx context.Context, rules []DetectionRule, opts TailOptions) (<-chan *Alert, error) {
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no detection rules provided")
+	}
+
+	out := make(chan *Alert)
+	go m.tailLoop(ctx, rules, opts, out)
+	return out, nil
+}
+
+func (m *Monitor) tailLoop(ctx context.Context, rules []DetectionRule, opts TailOptions, out chan<- *Alert) {
+	defer close(out)
+
+	state := &TailState{Rules: make(map[string]RuleState)}
+	if opts.StateFile != "" {
+		if loaded, err := LoadTailState(opts.StateFile); err == nil {
+			state = loaded
+		}
+	}
+
+	start := time.Now()
+	cursors := make(map[string]time.Time, len(rules))
+	for _, rule := range rules {
+		if rs, ok := state.Rules[rule.ID]; ok {
+			cursors[rule.ID] = rs.Cursor
+		} else {
+			cursors[rule.ID] = start
+		}
+	}
+
+	ticker := time.NewTicker(defaultTailPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		for _, rule := range rules {
+			since := cursors[rule.ID]
+			entries, err := m.fetchEntriesSince(ctx, rule.Filter, since, now)
+			if err != nil {
+				// Best-effort: skip this rule this tick, retry next tick.
+				continue
+			}
+
+			lastInsertID := state.Rules[rule.ID].LastInsertID
+			for _, entry := range entries {
+				if entry.InsertId != "" && entry.InsertId == lastInsertID {
+					continue
+				}
+				m.evaluateEntry(ctx, rule, entry, now, opts, out)
+				lastInsertID = entry.InsertId
+			}
+
+			cursors[rule.ID] = now
+			state.Rules[rule.ID] = RuleState{Cursor: now, LastInsertID: lastInsertID}
+		}
+
+		if opts.StateFile != "" {
+			if err := state.Save(opts.StateFile); err != nil {
+				// Best-effort: the next tick will try persisting again.
+				continue
+			}
+		}
+	}
+}
+
+// fetchEntriesSince lists log entries matching filter with a timestamp
+// constraint of (since, until], used by tailLoop as its polling cursor.
+func (m *Monitor) fetchEntriesSince(ctx context.Context, filter string, since, until time.Time) (_ []*googlelogging.LogEntry, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListLogEntries", start, err) }()
+
+	timeFilter := fmt.Sprintf(`timestamp > "%s" AND timestamp <= "%s"`, since.Format(time.RFC3339), until.Format(time.RFC3339))
+	combined := timeFilter
+	if filter != "" {
+		combined = fmt.Sprintf("%s AND %s", filter, timeFilter)
+	}
+
+	listCall := m.loggingService.Entries.List(&googlelogging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + m.projectID},
+		Filter:        combined,
+	})
+
+	var entries []*googlelogging.LogEntry
+	err = listCall.Pages(ctx, func(page *googlelogging.ListLogEntriesResponse) error {
+		entries = append(entries, page.Entries...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logs for rule: %w", err)
+	}
+	return entries, nil
+}
+
+// evaluateEntry checks entry against rule's CEL condition and, if it
+// matches and isn't currently throttled, emits an Alert on out and
+// delivers it via PublishAlert, or prints it to stdout if opts.DryRun.
+func (m *Monitor) evaluateEntry(ctx context.Context, rule DetectionRule, entry *googlelogging.LogEntry, now time.Time, opts TailOptions, out chan<- *Alert) {
+	decoded, err := entryToMap(entry)
+	if err != nil {
+		return
+	}
+
+	matched, err := rule.matches(decoded)
+	if err != nil || !matched {
+		return
+	}
+
+	key, err := rule.dedupeKey(decoded)
+	if err != nil {
+		return
+	}
+	if m.dedupe.Seen(key, rule.ThrottleDuration(), now) {
+		return
+	}
+
+	alert := &Alert{
+		Timestamp:   now,
+		Severity:    rule.Severity,
+		Description: rule.Description,
+		Resource:    entry.Resource.Type,
+		ProjectID:   m.projectID,
+		LogName:     entry.LogName,
+		Details: map[string]interface{}{
+			"rule_id":   rule.ID,
+			"insert_id": entry.InsertId,
+		},
+	}
+
+	select {
+	case out <- alert:
+	case <-ctx.Done():
+		return
+	}
+
+	if opts.DryRun {
+		printAlert(alert)
+		return
+	}
+	_ = m.PublishAlert(alert)
+}
+
+// printAlert writes alert as JSON to stdout, standing in for PublishAlert
+// when Tail is run with DryRun so alerts are visible without a live
+// Pub/Sub topic.
+func printAlert(alert *Alert) {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// entryToMap decodes a LogEntry into a generic map so it can be evaluated
+// by a CEL expression and rendered through a dedupe key template. A JSON
+// round-trip is used rather than hand-mapping fields so every field the
+// REST API returns (protoPayload, jsonPayload, resource labels, etc.) is
+// available to rule authors under its existing JSON name.
+func entryToMap(entry *googlelogging.LogEntry) (map[string]interface{}, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode log entry: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,58 @@
+package sa
+
+// ReportDiff summarizes what changed between a Report and its Baseline:
+// service accounts that newly became flagged (unused or over-privileged)
+// and accounts that were previously flagged but no longer are.
+type ReportDiff struct {
+	NewlyFlagged []*ServiceAccount `json:"newly_flagged,omitempty"`
+	Resolved     []*ServiceAccount `json:"resolved,omitempty"`
+}
+
+func isFlagged(sa *ServiceAccount) bool {
+	return !sa.IsUsed || sa.IsOverPriv
+}
+
+func flaggedByEmail(accounts []*ServiceAccount) map[string]*ServiceAccount {
+	flagged := make(map[string]*ServiceAccount, len(accounts))
+	for _, account := range accounts {
+		if isFlagged(account) {
+			flagged[account.Email] = account
+		}
+	}
+	return flagged
+}
+
+// DiffSinceBaseline compares report against report.Baseline and returns
+// which service accounts newly became flagged or were resolved. It
+// returns nil if report.Baseline is unset.
+func (r *Report) DiffSinceBaseline() *ReportDiff {
+	if r.Baseline == nil {
+		return nil
+	}
+	return DiffReports(r.Baseline, r)
+}
+
+// DiffReports compares two reports for the same project and returns
+// which service accounts newly became flagged or were resolved going
+// from old to new. Unlike DiffSinceBaseline, neither report needs its
+// Baseline field populated, which is what lets the archive subsystem
+// diff two arbitrary historical runs instead of only "current vs. most
+// recent".
+func DiffReports(old, new *Report) *ReportDiff {
+	current := flaggedByEmail(new.ServiceAccounts)
+	previous := flaggedByEmail(old.ServiceAccounts)
+
+	diff := &ReportDiff{}
+	for email, account := range current {
+		if _, ok := previous[email]; !ok {
+			diff.NewlyFlagged = append(diff.NewlyFlagged, account)
+		}
+	}
+	for email, account := range previous {
+		if _, ok := current[email]; !ok {
+			diff.Resolved = append(diff.Resolved, account)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,35 @@
+package sa
+
+import (
+	"fmt"
+
+	"github.com/hieptle/gcp-guardrail/pkg/report"
+)
+
+// ToFindings converts a Report's unused and over-privileged service
+// accounts into the shared report.Finding shape, mirroring
+// writeSARIFReport's two rules, so sa-tracker can hand its results to
+// pkg/report's GitHub Actions reporter alongside its own SARIF/JUnit/
+// JSON/CSV writers.
+func ToFindings(r *Report) []report.Finding {
+	var findings []report.Finding
+	for _, account := range r.ServiceAccounts {
+		if !account.IsUsed {
+			findings = append(findings, report.Finding{
+				RuleID:   "UnusedServiceAccount",
+				Severity: "LOW",
+				Resource: account.Email,
+				Message:  fmt.Sprintf("Service account %s has had no activity in the lookback window (%s)", account.Email, r.LookbackPeriod),
+			})
+		}
+		if account.IsOverPriv {
+			findings = append(findings, report.Finding{
+				RuleID:   "OverPrivilegedServiceAccount",
+				Severity: "HIGH",
+				Resource: account.Email,
+				Message:  fmt.Sprintf("Service account %s holds %d roles but shows little activity", account.Email, len(account.Roles)),
+			})
+		}
+	}
+	return findings
+}
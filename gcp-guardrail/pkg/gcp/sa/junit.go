@@ -0,0 +1,78 @@
+package sa
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitTestSuites is the JUnit XML root element. Each service account is a
+// <testcase>; unused or over-privileged accounts get a <failure> so CI
+// pipelines can fail the build on regressions.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name     string         `xml:"name,attr"`
+	Failures []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the report as JUnit XML.
+func writeJUnitReport(w io.Writer, report *Report) error {
+	suite := junitTestSuite{
+		Name: fmt.Sprintf("sa-tracker:%s", report.ProjectID),
+	}
+
+	for _, account := range report.ServiceAccounts {
+		testCase := junitTestCase{Name: account.Email}
+
+		if !account.IsUsed {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: "unused service account",
+				Text:    fmt.Sprintf("No activity observed in the last %s", report.LookbackPeriod),
+			})
+		}
+		if account.IsOverPriv {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: "over-privileged service account",
+				Text:    fmt.Sprintf("%d roles granted with low activity", len(account.Roles)),
+			})
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suite.Failures += len(testCase.Failures)
+	}
+
+	doc := junitTestSuites{
+		Name:     "sa-tracker",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := w.Write([]byte("\n"))
+	return err
+}
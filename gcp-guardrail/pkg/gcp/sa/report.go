@@ -20,6 +20,10 @@ const (
 	JSONFormat ReportFormat = "json"
 	// CSVFormat outputs the report in CSV format
 	CSVFormat ReportFormat = "csv"
+	// SARIFFormat outputs the report as SARIF 2.1.0 for GitHub Code Scanning and similar tools
+	SARIFFormat ReportFormat = "sarif"
+	// JUnitFormat outputs the report as JUnit XML so CI pipelines can fail on regressions
+	JUnitFormat ReportFormat = "junit"
 )
 
 // Report represents a service account usage report
@@ -28,7 +32,11 @@ type Report struct {
 	GeneratedAt     time.Time        `json:"generated_at"`
 	LookbackPeriod  string           `json:"lookback_period"`
 	ServiceAccounts []*ServiceAccount `json:"service_accounts"`
-	Stats           struct {
+	// Baseline is the most recent prior report for this project, set by
+	// callers that want the text/JSON/CSV writers to render a "new since
+	// last run" section (see DiffSinceBaseline).
+	Baseline *Report `json:"-"`
+	Stats    struct {
 		TotalAccounts      int `json:"total_accounts"`
 		UnusedAccounts     int `json:"unused_accounts"`
 		OverPrivAccounts   int `json:"over_privileged_accounts"`
@@ -76,6 +84,10 @@ func WriteReport(w io.Writer, report *Report, format ReportFormat) error {
 		return writeJSONReport(w, report)
 	case CSVFormat:
 		return writeCSVReport(w, report)
+	case SARIFFormat:
+		return writeSARIFReport(w, report)
+	case JUnitFormat:
+		return writeJUnitReport(w, report)
 	default:
 		return fmt.Errorf("unsupported report format: %s", format)
 	}
@@ -119,14 +131,41 @@ func writeTextReport(w io.Writer, report *Report) error {
 		
 		fmt.Fprintf(w, "\n")
 	}
-	
+
+	if report.Baseline != nil {
+		diff := report.DiffSinceBaseline()
+		fmt.Fprintf(w, "## NEW SINCE LAST RUN (baseline: %s)\n", report.Baseline.GeneratedAt.Format(time.RFC1123))
+		fmt.Fprintf(w, "Newly flagged: %d\n", len(diff.NewlyFlagged))
+		for i, account := range diff.NewlyFlagged {
+			fmt.Fprintf(w, "%d. %s (used: %t, over-privileged: %t)\n", i+1, account.Email, account.IsUsed, account.IsOverPriv)
+		}
+		fmt.Fprintf(w, "Resolved: %d\n\n", len(diff.Resolved))
+		for i, account := range diff.Resolved {
+			fmt.Fprintf(w, "%d. %s\n", i+1, account.Email)
+		}
+	}
+
 	return nil
 }
 
-// writeJSONReport writes the report in JSON format
+// reportWithDiff wraps a Report for JSON output when it carries a
+// Baseline, adding the computed diff alongside it rather than nesting
+// the entire prior report.
+type reportWithDiff struct {
+	*Report
+	NewSinceLastRun *ReportDiff `json:"new_since_last_run,omitempty"`
+}
+
+// writeJSONReport writes the report in JSON format. If the report carries
+// a Baseline, the computed diff is emitted alongside it under
+// new_since_last_run rather than nesting the entire prior report.
 func writeJSONReport(w io.Writer, report *Report) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
+
+	if report.Baseline != nil {
+		return encoder.Encode(reportWithDiff{Report: report, NewSinceLastRun: report.DiffSinceBaseline()})
+	}
 	return encoder.Encode(report)
 }
 
@@ -171,7 +210,33 @@ func writeCSVReport(w io.Writer, report *Report) error {
 	}
 	
 	csvWriter.Flush()
-	return csvWriter.Error()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+
+	if report.Baseline != nil {
+		diff := report.DiffSinceBaseline()
+		fmt.Fprintf(w, "\nNew Since Last Run (baseline: %s)\n", report.Baseline.GeneratedAt.Format(time.RFC3339))
+
+		diffWriter := csv.NewWriter(w)
+		if err := diffWriter.Write([]string{"Status", "Email", "Is Used", "Is Over-Privileged"}); err != nil {
+			return err
+		}
+		for _, account := range diff.NewlyFlagged {
+			if err := diffWriter.Write([]string{"NEWLY_FLAGGED", account.Email, strconv.FormatBool(account.IsUsed), strconv.FormatBool(account.IsOverPriv)}); err != nil {
+				return err
+			}
+		}
+		for _, account := range diff.Resolved {
+			if err := diffWriter.Write([]string{"RESOLVED", account.Email, strconv.FormatBool(account.IsUsed), strconv.FormatBool(account.IsOverPriv)}); err != nil {
+				return err
+			}
+		}
+		diffWriter.Flush()
+		return diffWriter.Error()
+	}
+
+	return nil
 }
 
 // formatLastUsed formats the last used time for display
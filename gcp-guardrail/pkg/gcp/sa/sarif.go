@@ -0,0 +1,114 @@
+package sa
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifLog is the root SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// writeSARIFReport writes the report as SARIF 2.1.0 JSON. Unused and
+// over-privileged service accounts each become one result, under the
+// "UnusedServiceAccount" and "OverPrivilegedServiceAccount" rule ids
+// respectively.
+func writeSARIFReport(w io.Writer, report *Report) error {
+	const (
+		unusedRule       = "UnusedServiceAccount"
+		overPrivRule     = "OverPrivilegedServiceAccount"
+	)
+
+	var results []sarifResult
+	for _, account := range report.ServiceAccounts {
+		if !account.IsUsed {
+			results = append(results, sarifResult{
+				RuleID: unusedRule,
+				Level:  "warning",
+				Message: sarifMessage{
+					Text: fmt.Sprintf("Service account %s has had no activity in the lookback window (%s)", account.Email, report.LookbackPeriod),
+				},
+				Locations: []sarifLocation{
+					{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: account.Email}}},
+				},
+			})
+		}
+		if account.IsOverPriv {
+			results = append(results, sarifResult{
+				RuleID: overPrivRule,
+				Level:  "error",
+				Message: sarifMessage{
+					Text: fmt.Sprintf("Service account %s holds %d roles but shows little activity", account.Email, len(account.Roles)),
+				},
+				Locations: []sarifLocation{
+					{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: account.Email}}},
+				},
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name: "gcpgolang-sa-tracker",
+						Rules: []sarifRule{
+							{ID: unusedRule, Name: unusedRule},
+							{ID: overPrivRule, Name: overPrivRule},
+						},
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -3,12 +3,69 @@ package sa
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
+	asset "cloud.google.com/go/asset/apiv1"
+	"cloud.google.com/go/asset/apiv1/assetpb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/logging/v2"
 )
 
+// tracer and meter instrument AnalyzeUsage/checkActivity so scheduled runs
+// can be observed for API cost and error rates via OTLP.
+var (
+	tracer = otel.Tracer("github.com/hieptle/gcp-guardrail/pkg/gcp/sa")
+	meter  = otel.Meter("github.com/hieptle/gcp-guardrail/pkg/gcp/sa")
+
+	issuesFoundCounter  metric.Int64Counter
+	apiCallsCounter     metric.Int64Counter
+	apiLatencyHistogram metric.Float64Histogram
+)
+
+func init() {
+	var err error
+	issuesFoundCounter, err = meter.Int64Counter(
+		"gcpgolang.sa.issues_found",
+		metric.WithDescription("Number of unused or over-privileged service accounts found per AnalyzeUsage run"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("sa: failed to register issues_found counter: %v", err))
+	}
+
+	apiCallsCounter, err = meter.Int64Counter(
+		"gcpgolang.sa.api_calls",
+		metric.WithDescription("Number of GCP API calls made by the service account tracker, by method"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("sa: failed to register api_calls counter: %v", err))
+	}
+
+	apiLatencyHistogram, err = meter.Float64Histogram(
+		"gcpgolang.sa.api_latency",
+		metric.WithDescription("Latency of GCP API calls made by the service account tracker, in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("sa: failed to register api_latency histogram: %v", err))
+	}
+}
+
+// recordAPICall records one API call's outcome and latency under the
+// api-calls and api-latency instruments, tagged with the method name and
+// whether it failed.
+func recordAPICall(ctx context.Context, method string, start time.Time, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.Bool("error", err != nil),
+	)
+	apiCallsCounter.Add(ctx, 1, attrs)
+	apiLatencyHistogram.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
 // ServiceAccount represents a GCP service account with usage information
 type ServiceAccount struct {
 	Email         string    `json:"email"`
@@ -24,9 +81,11 @@ type ServiceAccount struct {
 
 // Tracker analyzes service account usage in a GCP project
 type Tracker struct {
-	projectID         string
-	loggingService    *logging.Service
-	ctx               context.Context
+	projectID      string
+	loggingService *logging.Service
+	iamService     *iam.Service
+	assetClient    *asset.Client
+	ctx            context.Context
 }
 
 // NewTracker creates a new service account tracker for a GCP project
@@ -37,42 +96,49 @@ func NewTracker(ctx context.Context, projectID string) (*Tracker, error) {
 		return nil, fmt.Errorf("failed to create logging service: %w", err)
 	}
 
+	// Create IAM Admin service for service account and key enumeration
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IAM service: %w", err)
+	}
+
+	// Create Cloud Asset client for project-level IAM policy lookups, the
+	// same RPC pkg/gcp/iam's CloudAssetFetcher uses to resolve a
+	// principal's granted roles.
+	assetClient, err := asset.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud asset client: %w", err)
+	}
+
 	return &Tracker{
 		projectID:      projectID,
 		loggingService: loggingService,
+		iamService:     iamService,
+		assetClient:    assetClient,
 		ctx:            ctx,
 	}, nil
 }
 
 // AnalyzeUsage analyzes service account usage over a period of time
 func (t *Tracker) AnalyzeUsage(lookbackPeriod time.Duration) ([]*ServiceAccount, error) {
-	// For this implementation, we'll simulate fetching service accounts
-	// In a real implementation, you would use the IAM Admin API
-	serviceAccounts := []*ServiceAccount{
-		{
-			Email:       fmt.Sprintf("sa-1@%s.iam.gserviceaccount.com", t.projectID),
-			DisplayName: "Service Account 1",
-			Created:     time.Now().Add(-90 * 24 * time.Hour),
-		},
-		{
-			Email:       fmt.Sprintf("sa-2@%s.iam.gserviceaccount.com", t.projectID),
-			DisplayName: "Service Account 2",
-			Created:     time.Now().Add(-60 * 24 * time.Hour),
-		},
-		{
-			Email:       fmt.Sprintf("sa-3@%s.iam.gserviceaccount.com", t.projectID),
-			DisplayName: "Service Account 3",
-			Created:     time.Now().Add(-30 * 24 * time.Hour),
-		},
+	ctx, span := tracer.Start(t.ctx, "sa.AnalyzeUsage")
+	defer span.End()
+
+	serviceAccounts, err := t.listServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
 	}
 
 	// For each service account, analyze usage
 	for _, sa := range serviceAccounts {
-		// Simulate key count data
-		sa.KeyCount = len(sa.Email) % 3
+		// Count active keys
+		keyCount, err := t.countKeys(ctx, sa.Email)
+		if err == nil {
+			sa.KeyCount = keyCount
+		}
 
 		// Check activity logs
-		lastUsed, activityCount, err := t.checkActivity(sa.Email, lookbackPeriod)
+		lastUsed, activityCount, err := t.checkActivity(ctx, sa.Email, lookbackPeriod)
 		if err == nil {
 			sa.LastUsed = lastUsed
 			sa.ActivityCount = activityCount
@@ -80,7 +146,7 @@ func (t *Tracker) AnalyzeUsage(lookbackPeriod time.Duration) ([]*ServiceAccount,
 		}
 
 		// Get roles
-		roles, err := t.getRoles(sa.Email)
+		roles, err := t.getRoles(ctx, sa.Email)
 		if err == nil {
 			sa.Roles = roles
 			// Simple heuristic for over-privileged accounts
@@ -88,25 +154,78 @@ func (t *Tracker) AnalyzeUsage(lookbackPeriod time.Duration) ([]*ServiceAccount,
 		}
 	}
 
+	var flagged int64
+	for _, sa := range serviceAccounts {
+		if !sa.IsUsed || sa.IsOverPriv {
+			flagged++
+		}
+	}
+	issuesFoundCounter.Add(ctx, flagged)
+
 	return serviceAccounts, nil
 }
 
+// listServiceAccounts paginates through Projects.ServiceAccounts.List for the tracked project.
+func (t *Tracker) listServiceAccounts(ctx context.Context) (_ []*ServiceAccount, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListServiceAccounts", start, err) }()
+
+	var serviceAccounts []*ServiceAccount
+
+	name := "projects/" + t.projectID
+	call := t.iamService.Projects.ServiceAccounts.List(name)
+	err = call.Pages(ctx, func(page *iam.ListServiceAccountsResponse) error {
+		for _, account := range page.Accounts {
+			serviceAccounts = append(serviceAccounts, &ServiceAccount{
+				Email:       account.Email,
+				DisplayName: account.DisplayName,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceAccounts, nil
+}
+
+// countKeys counts the user-managed keys for a service account.
+func (t *Tracker) countKeys(ctx context.Context, email string) (_ int, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListServiceAccountKeys", start, err) }()
+
+	name := fmt.Sprintf("projects/%s/serviceAccounts/%s", t.projectID, email)
+	resp, err := t.iamService.Projects.ServiceAccounts.Keys.List(name).KeyTypes("USER_MANAGED").Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list keys for %s: %w", email, err)
+	}
+
+	return len(resp.Keys), nil
+}
+
 // checkActivity checks activity logs for a service account
-func (t *Tracker) checkActivity(email string, lookbackPeriod time.Duration) (time.Time, int, error) {
+func (t *Tracker) checkActivity(ctx context.Context, email string, lookbackPeriod time.Duration) (_ time.Time, _ int, err error) {
+	ctx, span := tracer.Start(ctx, "sa.checkActivity")
+	defer span.End()
+
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "ListLogEntries", start, err) }()
+
 	var lastUsed time.Time
 	activityCount := 0
-	
+
 	endTime := time.Now()
 	startTime := endTime.Add(-lookbackPeriod)
-	
+
 	// Format timestamps for the filter
 	startTimeStr := startTime.Format(time.RFC3339)
 	endTimeStr := endTime.Format(time.RFC3339)
-	
+
 	// Create filter for this service account's activity
 	filter := fmt.Sprintf(`protoPayload.authenticationInfo.principalEmail="%s" AND timestamp >= "%s" AND timestamp <= "%s"`,
 		email, startTimeStr, endTimeStr)
-	
+
 	// Create the entries list call
 	entriesService := t.loggingService.Entries
 	listCall := entriesService.List(&logging.ListLogEntriesRequest{
@@ -115,12 +234,12 @@ func (t *Tracker) checkActivity(email string, lookbackPeriod time.Duration) (tim
 		OrderBy:       "timestamp desc",
 		PageSize:      1000, // Limit to reasonable number
 	})
-	
+
 	// Collect log entries
-	err := listCall.Pages(t.ctx, func(page *logging.ListLogEntriesResponse) error {
+	err = listCall.Pages(ctx, func(page *logging.ListLogEntriesResponse) error {
 		for i, entry := range page.Entries {
 			activityCount++
-			
+
 			// Record the timestamp of the most recent activity (first entry)
 			if i == 0 && lastUsed.IsZero() {
 				// Convert timestamp string to time.Time
@@ -131,30 +250,56 @@ func (t *Tracker) checkActivity(email string, lookbackPeriod time.Duration) (tim
 		}
 		return nil
 	})
-	
+
 	if err != nil {
 		return lastUsed, activityCount, fmt.Errorf("failed to check activity logs: %w", err)
 	}
-	
+
 	return lastUsed, activityCount, nil
 }
 
-// getRoles gets the roles assigned to a service account
-func (t *Tracker) getRoles(email string) ([]string, error) {
-	// In a real implementation, you would fetch the IAM policy and extract roles
-	// Here we're returning simulated data based on the email to use strconv
-	numRoles := len(email) % 5 + 2 // 2-6 roles
-	roles := make([]string, numRoles)
-	
-	for i := 0; i < numRoles; i++ {
-		roleNum := strconv.Itoa(i + 1)
-		roles[i] = fmt.Sprintf("roles/role%s", roleNum)
+// getRoles gets the roles assigned to a service account by searching the
+// project's IAM policy bindings and collecting every role granted to the
+// account's member identity. Note this is distinct from
+// Projects.ServiceAccounts.GetIamPolicy, which returns who can impersonate
+// the service account, not what the service account itself can do.
+func (t *Tracker) getRoles(ctx context.Context, email string) (_ []string, err error) {
+	start := time.Now()
+	defer func() { recordAPICall(ctx, "SearchAllIamPolicies", start, err) }()
+
+	member := "serviceAccount:" + email
+
+	var roles []string
+	it := t.assetClient.SearchAllIamPolicies(ctx, &assetpb.SearchAllIamPoliciesRequest{
+		Scope: "projects/" + t.projectID,
+	})
+	for {
+		result, itErr := it.Next()
+		if itErr == iterator.Done {
+			break
+		}
+		if itErr != nil {
+			err = fmt.Errorf("failed to search IAM policies for %s: %w", email, itErr)
+			return nil, err
+		}
+
+		if result.Policy == nil {
+			continue
+		}
+		for _, binding := range result.Policy.Bindings {
+			for _, m := range binding.Members {
+				if m == member {
+					roles = append(roles, binding.Role)
+					break
+				}
+			}
+		}
 	}
-	
+
 	return roles, nil
 }
 
 // Close closes the tracker and releases resources
 func (t *Tracker) Close() error {
-	return nil
+	return t.assetClient.Close()
 } 
\ No newline at end of file
@@ -0,0 +1,99 @@
+package rego
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AdmissionReview mirrors the Kubernetes admission/v1 AdmissionReview
+// object, scoped to what EvaluateAdmission needs: gcp-guardrail only ever
+// responds to a webhook request, so Response is only ever populated on
+// the way out.
+type AdmissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *AdmissionRequest  `json:"request,omitempty"`
+	Response   *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest is the part of a Kubernetes AdmissionRequest
+// EvaluateAdmission reads: the object being admitted, plus enough
+// surrounding context (namespace/operation/kind) for a constraint to
+// condition on.
+type AdmissionRequest struct {
+	UID       string                 `json:"uid"`
+	Kind      map[string]interface{} `json:"kind,omitempty"`
+	Resource  map[string]interface{} `json:"resource,omitempty"`
+	Namespace string                 `json:"namespace,omitempty"`
+	Operation string                 `json:"operation,omitempty"`
+	Object    map[string]interface{} `json:"object"`
+	OldObject map[string]interface{} `json:"oldObject,omitempty"`
+}
+
+// AdmissionResponse is the part of a Kubernetes AdmissionResponse
+// EvaluateAdmission produces.
+type AdmissionResponse struct {
+	UID       string   `json:"uid"`
+	Allowed   bool     `json:"allowed"`
+	Warnings  []string `json:"warnings,omitempty"`
+	Patch     []byte   `json:"patch,omitempty"`
+	PatchType *string  `json:"patchType,omitempty"`
+}
+
+// EvaluateAdmission evaluates review.Request.Object against every
+// Constraint registered via AddConstraint and folds the results into one
+// AdmissionResponse. gcp-guardrail's playground is a policy IDE and
+// dry-run harness rather than a real enforcing webhook: every constraint
+// violation both denies the request (Allowed is false) and is surfaced
+// as a warning, so users see the same outcome a real Gatekeeper install
+// would produce without anything here actually touching a cluster.
+func (e *Evaluator) EvaluateAdmission(review *AdmissionReview) (*AdmissionResponse, error) {
+	if review.Request == nil {
+		return nil, fmt.Errorf("AdmissionReview is missing request")
+	}
+
+	reviewInput := map[string]interface{}{
+		"object":    review.Request.Object,
+		"oldObject": review.Request.OldObject,
+		"operation": review.Request.Operation,
+		"namespace": review.Request.Namespace,
+		"kind":      review.Request.Kind,
+		"resource":  review.Request.Resource,
+	}
+
+	response := &AdmissionResponse{UID: review.Request.UID, Allowed: true}
+
+	var patches []PatchOp
+	for _, constraint := range e.constraints {
+		result, err := e.EvaluateConstraint(constraint, reviewInput)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range result.Violations {
+			response.Warnings = append(response.Warnings, v.Message)
+			response.Allowed = false
+		}
+
+		tmpl := e.templates[constraint.Kind]
+		ops, err := e.EvaluateMutation(tmpl.PackagePath, map[string]interface{}{
+			"review":     reviewInput,
+			"parameters": constraint.Parameters,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("patch rule evaluation failed for %s: %w", tmpl.PackagePath, err)
+		}
+		patches = append(patches, ops...)
+	}
+
+	if len(patches) > 0 {
+		encoded, err := json.Marshal(patches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode admission patch: %w", err)
+		}
+		response.Patch = encoded
+		patchType := "JSONPatch"
+		response.PatchType = &patchType
+	}
+
+	return response, nil
+}
@@ -0,0 +1,173 @@
+package rego
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"gopkg.in/yaml.v3"
+)
+
+// ConstraintTemplate is a Gatekeeper-style reusable policy: a Rego module
+// plus a CRD-like schema describing the parameters a Constraint
+// instantiating it may set. It's parsed from the same YAML shape as a
+// real Kubernetes ConstraintTemplate custom resource (apiVersion, kind:
+// ConstraintTemplate, spec.crd.spec.names.kind,
+// spec.crd.spec.validation.openAPIV3Schema, spec.targets[0].rego), so
+// policies authored in the playground can be deployed to a real
+// Gatekeeper install unchanged.
+type ConstraintTemplate struct {
+	// Kind is the Constraint kind this template defines (e.g.
+	// "K8sRequiredLabels"), from spec.crd.spec.names.kind.
+	Kind string
+	// Schema is the constraint's parameter schema, from
+	// spec.crd.spec.validation.openAPIV3Schema, exposed as-is for the
+	// playground UI to render a parameter form from.
+	Schema map[string]interface{}
+	// PackagePath is the dotted Rego package path declared by the
+	// template's rego source (spec.targets[0].rego), used to query its
+	// "violation" and "patch" rules.
+	PackagePath string
+
+	module *ast.Module
+}
+
+// constraintTemplateYAML mirrors the subset of a Kubernetes
+// ConstraintTemplate custom resource ParseConstraintTemplate needs.
+type constraintTemplateYAML struct {
+	Spec struct {
+		CRD struct {
+			Spec struct {
+				Names struct {
+					Kind string `yaml:"kind"`
+				} `yaml:"names"`
+				Validation struct {
+					OpenAPIV3Schema map[string]interface{} `yaml:"openAPIV3Schema"`
+				} `yaml:"validation"`
+			} `yaml:"spec"`
+		} `yaml:"crd"`
+		Targets []struct {
+			Target string `yaml:"target"`
+			Rego   string `yaml:"rego"`
+		} `yaml:"targets"`
+	} `yaml:"spec"`
+}
+
+// ParseConstraintTemplate parses a ConstraintTemplate YAML document,
+// compiling its first target's Rego source so callers get a parse error
+// up front rather than on first evaluation.
+func ParseConstraintTemplate(data []byte) (*ConstraintTemplate, error) {
+	var doc constraintTemplateYAML
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse ConstraintTemplate: %w", err)
+	}
+
+	kind := doc.Spec.CRD.Spec.Names.Kind
+	if kind == "" {
+		return nil, fmt.Errorf("ConstraintTemplate is missing spec.crd.spec.names.kind")
+	}
+	if len(doc.Spec.Targets) == 0 || doc.Spec.Targets[0].Rego == "" {
+		return nil, fmt.Errorf("ConstraintTemplate %q has no spec.targets[0].rego", kind)
+	}
+
+	module, err := ast.ParseModule(kind+".rego", doc.Spec.Targets[0].Rego)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rego for ConstraintTemplate %q: %w", kind, err)
+	}
+
+	return &ConstraintTemplate{
+		Kind:        kind,
+		Schema:      doc.Spec.CRD.Spec.Validation.OpenAPIV3Schema,
+		PackagePath: packagePathString(module.Package.Path),
+		module:      module,
+	}, nil
+}
+
+// Constraint instantiates a ConstraintTemplate with user-supplied
+// parameters, mirroring a Kubernetes Constraint custom resource (kind:
+// <template's Kind>, spec.parameters).
+type Constraint struct {
+	Kind       string
+	Parameters map[string]interface{}
+}
+
+type constraintYAML struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Parameters map[string]interface{} `yaml:"parameters"`
+	} `yaml:"spec"`
+}
+
+// ParseConstraint parses a Constraint YAML document (kind: <template's
+// Kind>, spec.parameters).
+func ParseConstraint(data []byte) (*Constraint, error) {
+	var doc constraintYAML
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Constraint: %w", err)
+	}
+	if doc.Kind == "" {
+		return nil, fmt.Errorf("Constraint is missing kind")
+	}
+
+	return &Constraint{Kind: doc.Kind, Parameters: doc.Spec.Parameters}, nil
+}
+
+// AddConstraintTemplate registers tmpl's Rego module with the evaluator,
+// making its Kind available to AddConstraint/EvaluateConstraint.
+// Registering a template under a Kind that's already registered replaces
+// it.
+func (e *Evaluator) AddConstraintTemplate(tmpl *ConstraintTemplate) {
+	e.templates[tmpl.Kind] = tmpl
+	e.modules["constrainttemplate:"+tmpl.Kind] = tmpl.module
+}
+
+// AddConstraint registers constraint so EvaluateAdmission includes it,
+// returning an error if no ConstraintTemplate of its Kind was registered
+// via AddConstraintTemplate.
+func (e *Evaluator) AddConstraint(constraint *Constraint) error {
+	if _, ok := e.templates[constraint.Kind]; !ok {
+		return fmt.Errorf("no ConstraintTemplate registered for kind %q", constraint.Kind)
+	}
+	e.constraints = append(e.constraints, constraint)
+	return nil
+}
+
+// EvaluateConstraint evaluates constraint's ConstraintTemplate against
+// review, matching Gatekeeper's own input convention: input.review
+// carries the object under review (typically built by EvaluateAdmission)
+// and input.parameters carries the Constraint's parameters. The
+// template's "violation" rule produces the EvaluationResult's
+// Violations; Gatekeeper templates don't distinguish warn from deny, so
+// unlike Evaluate there's no separate warn query here.
+func (e *Evaluator) EvaluateConstraint(constraint *Constraint, review map[string]interface{}) (*EvaluationResult, error) {
+	tmpl, ok := e.templates[constraint.Kind]
+	if !ok {
+		return nil, fmt.Errorf("no ConstraintTemplate registered for kind %q", constraint.Kind)
+	}
+
+	input := map[string]interface{}{
+		"review":     review,
+		"parameters": constraint.Parameters,
+	}
+
+	query := e.newRego(
+		rego.Query(fmt.Sprintf("data.%s.violation", tmpl.PackagePath)),
+		rego.Store(e.store),
+		rego.Input(input),
+	)
+
+	rs, err := query.Eval(e.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("violation rule evaluation failed for %s: %w", tmpl.PackagePath, err)
+	}
+
+	result := &EvaluationResult{}
+	if len(rs) > 0 && len(rs[0].Expressions) > 0 && rs[0].Expressions[0].Value != nil {
+		for _, v := range rs[0].Expressions[0].Value.([]interface{}) {
+			result.Violations = append(result.Violations, parseViolation(v, tmpl.PackagePath, "ERROR"))
+		}
+	}
+	result.FailCount = len(result.Violations)
+
+	return result, nil
+}
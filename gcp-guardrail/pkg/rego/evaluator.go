@@ -2,6 +2,7 @@ package rego
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,12 +30,69 @@ type EvaluationResult struct {
 	WarnCount  int         `json:"warn_count"`
 }
 
+// Enforcement is a policy's optional Gatekeeper-style self-declared
+// enforcement metadata, e.g. `enforcement := {"actions": ["dryrun"],
+// "scope": "plan"}`. A policy with no enforcement rule has no
+// Enforcement (EvaluateEnforcement returns nil), and callers should
+// treat that the same as an unscoped "deny" policy for backward
+// compatibility with policies written before this existed.
+type Enforcement struct {
+	Actions []string `json:"actions"`
+	Scope   string   `json:"scope"`
+}
+
+// HasAction reports whether e declares action among its Actions.
+func (e *Enforcement) HasAction(action string) bool {
+	if e == nil {
+		return action == "deny"
+	}
+	for _, a := range e.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeOrDefault returns e's declared Scope, or "" if e is nil or
+// declares no scope.
+func (e *Enforcement) ScopeOrDefault() string {
+	if e == nil {
+		return ""
+	}
+	return e.Scope
+}
+
+// AppliesToScope reports whether e's declared scope matches the given
+// scope. A policy with no declared scope applies to every scope.
+func (e *Enforcement) AppliesToScope(scope string) bool {
+	if e == nil || e.Scope == "" {
+		return true
+	}
+	return e.Scope == scope
+}
+
+// PatchOp is a single RFC 6902 JSON Patch operation, as returned by a
+// mutation policy's "patch" rule (e.g. {"op": "add", "path":
+// "/spec/securityContext", "value": {...}}).
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
 // Evaluator is responsible for evaluating OPA policies
 type Evaluator struct {
 	policyDirs []string
 	modules    map[string]*ast.Module
 	ctx        context.Context
 	store      storage.Store
+
+	// templates and constraints back EvaluateConstraint/EvaluateAdmission:
+	// templates is keyed by ConstraintTemplate.Kind, and constraints holds
+	// every Constraint instantiating one, in registration order.
+	templates   map[string]*ConstraintTemplate
+	constraints []*Constraint
 }
 
 // NewEvaluator creates a new OPA policy evaluator
@@ -44,6 +102,7 @@ func NewEvaluator(ctx context.Context, policyDirs []string) (*Evaluator, error)
 		modules:    make(map[string]*ast.Module),
 		ctx:        ctx,
 		store:      inmem.New(),
+		templates:  make(map[string]*ConstraintTemplate),
 	}
 
 	if err := e.loadPolicies(); err != nil {
@@ -53,6 +112,38 @@ func NewEvaluator(ctx context.Context, policyDirs []string) (*Evaluator, error)
 	return e, nil
 }
 
+// moduleOptions returns one rego.ParsedModule option per loaded module.
+// rego.New has no single option for loading a map of modules at once, so
+// each one needs its own functional option, spread into rego.New alongside
+// a call's other options.
+func (e *Evaluator) moduleOptions() []func(*rego.Rego) {
+	opts := make([]func(*rego.Rego), 0, len(e.modules))
+	for _, module := range e.modules {
+		opts = append(opts, rego.ParsedModule(module))
+	}
+	return opts
+}
+
+// newRego builds a *rego.Rego from opts plus every module currently loaded
+// into e.
+func (e *Evaluator) newRego(opts ...func(*rego.Rego)) *rego.Rego {
+	return rego.New(append(opts, e.moduleOptions()...)...)
+}
+
+// packagePathString renders an ast.Package's Path -- an ast.Ref whose
+// first term is always the "data" root Var -- as the dotted string
+// Evaluate/EvaluateConstraint expect, e.g.
+// "terraform.gcp.google_storage_bucket".
+func packagePathString(path ast.Ref) string {
+	parts := make([]string, 0, len(path)-1)
+	for _, term := range path[1:] {
+		if s, ok := term.Value.(ast.String); ok {
+			parts = append(parts, string(s))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
 // loadPolicies loads all .rego files from the specified directories
 func (e *Evaluator) loadPolicies() error {
 	for _, dir := range e.policyDirs {
@@ -89,29 +180,35 @@ func (e *Evaluator) loadPolicies() error {
 	return nil
 }
 
+// parseViolation converts a single deny/warn rule result into a
+// Violation. Rules may return a plain string message, in which case
+// defaultSeverity applies, or an object with "msg" and "severity" keys
+// (the Gatekeeper constraint-violation convention) to set their own
+// severity (e.g. CRITICAL/HIGH/MEDIUM/LOW).
+func parseViolation(v interface{}, packagePath, defaultSeverity string) Violation {
+	switch val := v.(type) {
+	case string:
+		return Violation{Message: val, Severity: defaultSeverity, Policy: packagePath}
+	case map[string]interface{}:
+		msg, _ := val["msg"].(string)
+		severity, _ := val["severity"].(string)
+		if severity == "" {
+			severity = defaultSeverity
+		}
+		return Violation{Message: msg, Severity: strings.ToUpper(severity), Policy: packagePath}
+	default:
+		return Violation{Message: fmt.Sprintf("%v", val), Severity: defaultSeverity, Policy: packagePath}
+	}
+}
+
 // Evaluate evaluates the given input against loaded policies
 func (e *Evaluator) Evaluate(packagePath string, input interface{}) (*EvaluationResult, error) {
 	result := &EvaluationResult{}
 
-	// Create a new Rego instance
-	r := rego.New(
-		rego.Query(fmt.Sprintf("data.%s", packagePath)),
-		rego.Store(e.store),
-		rego.Modules(e.modules),
-		rego.Input(input),
-	)
-
-	// Run the evaluation
-	rs, err := r.Eval(e.ctx)
-	if err != nil {
-		return nil, fmt.Errorf("evaluation failed: %w", err)
-	}
-
 	// Process deny rules
-	denyQuery := rego.New(
+	denyQuery := e.newRego(
 		rego.Query(fmt.Sprintf("data.%s.deny", packagePath)),
 		rego.Store(e.store),
-		rego.Modules(e.modules),
 		rego.Input(input),
 	)
 
@@ -121,10 +218,9 @@ func (e *Evaluator) Evaluate(packagePath string, input interface{}) (*Evaluation
 	}
 
 	// Process warnings
-	warnQuery := rego.New(
+	warnQuery := e.newRego(
 		rego.Query(fmt.Sprintf("data.%s.warn", packagePath)),
 		rego.Store(e.store),
-		rego.Modules(e.modules),
 		rego.Input(input),
 	)
 
@@ -138,11 +234,7 @@ func (e *Evaluator) Evaluate(packagePath string, input interface{}) (*Evaluation
 		violations := denyRs[0].Expressions[0].Value
 		if violations != nil {
 			for _, v := range violations.([]interface{}) {
-				result.Violations = append(result.Violations, Violation{
-					Message:  v.(string),
-					Severity: "ERROR",
-					Policy:   packagePath,
-				})
+				result.Violations = append(result.Violations, parseViolation(v, packagePath, "ERROR"))
 			}
 		}
 	}
@@ -152,11 +244,7 @@ func (e *Evaluator) Evaluate(packagePath string, input interface{}) (*Evaluation
 		warnings := warnRs[0].Expressions[0].Value
 		if warnings != nil {
 			for _, w := range warnings.([]interface{}) {
-				result.Warnings = append(result.Warnings, Violation{
-					Message:  w.(string),
-					Severity: "WARNING",
-					Policy:   packagePath,
-				})
+				result.Warnings = append(result.Warnings, parseViolation(w, packagePath, "WARNING"))
 			}
 		}
 	}
@@ -168,6 +256,125 @@ func (e *Evaluator) Evaluate(packagePath string, input interface{}) (*Evaluation
 	return result, nil
 }
 
+// EvaluateEnforcement evaluates the "enforcement" rule under packagePath,
+// if the policy declares one. It returns nil (not an error) when the
+// policy has no enforcement rule defined.
+func (e *Evaluator) EvaluateEnforcement(packagePath string) (*Enforcement, error) {
+	query := e.newRego(
+		rego.Query(fmt.Sprintf("data.%s.enforcement", packagePath)),
+		rego.Store(e.store),
+	)
+
+	rs, err := query.Eval(e.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("enforcement rule evaluation failed: %w", err)
+	}
+
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 || rs[0].Expressions[0].Value == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(rs[0].Expressions[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode enforcement metadata for %s: %w", packagePath, err)
+	}
+
+	var enforcement Enforcement
+	if err := json.Unmarshal(encoded, &enforcement); err != nil {
+		return nil, fmt.Errorf("failed to decode enforcement metadata for %s: %w", packagePath, err)
+	}
+
+	return &enforcement, nil
+}
+
+// EvaluateMutation evaluates the "patch" rule under packagePath (e.g.
+// kubernetes.mutation.pod) and returns the JSON Patch operations it
+// produces. Unlike Evaluate's deny/warn rules, a mutation policy emits
+// patch operations rather than violation messages, so callers build
+// these into an RFC 6902 JSON Patch instead of denying the request.
+func (e *Evaluator) EvaluateMutation(packagePath string, input interface{}) ([]PatchOp, error) {
+	query := e.newRego(
+		rego.Query(fmt.Sprintf("data.%s.patch", packagePath)),
+		rego.Store(e.store),
+		rego.Input(input),
+	)
+
+	rs, err := query.Eval(e.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("mutation rule evaluation failed: %w", err)
+	}
+
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 || rs[0].Expressions[0].Value == nil {
+		return nil, nil
+	}
+
+	raw, ok := rs[0].Expressions[0].Value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mutation rule for %s did not return a list of patch operations", packagePath)
+	}
+
+	// Round-trip through JSON rather than hand-walking the map[string]any
+	// values OPA returns, since PatchOp's Value field needs to preserve
+	// whatever arbitrary JSON the policy produced.
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patch operations for %s: %w", packagePath, err)
+	}
+
+	var ops []PatchOp
+	if err := json.Unmarshal(encoded, &ops); err != nil {
+		return nil, fmt.Errorf("failed to decode patch operations for %s: %w", packagePath, err)
+	}
+
+	return ops, nil
+}
+
+// Stage is one step of a chained EvaluateChain evaluation, modeled on
+// Digger's plan-policy-then-access-policy flow: a "plan" stage scores a
+// Terraform plan, and an "access" stage decides whether an actor may
+// apply it, with the plan stage's result exposed to the access stage's
+// input.
+type Stage struct {
+	// PackagePath is the Rego package to evaluate for this stage.
+	PackagePath string
+	// Input is this stage's own base input document. FromStage entries
+	// are merged into a copy of it before evaluation.
+	Input map[string]interface{}
+	// FromStage maps an input key to the index of an earlier stage in
+	// the chain whose EvaluationResult should be exposed under that key,
+	// e.g. {"plan_policy": 0} to expose stage 0's result as
+	// input.plan_policy for this stage.
+	FromStage map[string]int
+}
+
+// EvaluateChain runs each Stage in order, merging the named results of
+// earlier stages into later stages' input per their FromStage mapping,
+// and returns every stage's EvaluationResult in order.
+func (e *Evaluator) EvaluateChain(stages []Stage) ([]*EvaluationResult, error) {
+	results := make([]*EvaluationResult, 0, len(stages))
+
+	for i, stage := range stages {
+		input := make(map[string]interface{}, len(stage.Input)+len(stage.FromStage))
+		for k, v := range stage.Input {
+			input[k] = v
+		}
+		for key, idx := range stage.FromStage {
+			if idx < 0 || idx >= len(results) {
+				return nil, fmt.Errorf("chain stage %d (%s): FromStage[%q] references stage %d, which hasn't run yet", i, stage.PackagePath, key, idx)
+			}
+			input[key] = results[idx]
+		}
+
+		result, err := e.Evaluate(stage.PackagePath, input)
+		if err != nil {
+			return nil, fmt.Errorf("chain stage %d (%s) failed: %w", i, stage.PackagePath, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // EvaluateAll evaluates input against all loaded policies
 func (e *Evaluator) EvaluateAll(input interface{}) ([]*EvaluationResult, error) {
 	var results []*EvaluationResult
@@ -175,7 +382,7 @@ func (e *Evaluator) EvaluateAll(input interface{}) ([]*EvaluationResult, error)
 	// Get unique package paths from the loaded modules
 	packagePaths := make(map[string]bool)
 	for _, module := range e.modules {
-		packagePath := strings.Join(module.Package.Path, ".")
+		packagePath := packagePathString(module.Package.Path)
 		packagePaths[packagePath] = true
 	}
 
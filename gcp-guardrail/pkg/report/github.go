@@ -0,0 +1,59 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// githubLevel maps a Finding severity to a GitHub Actions workflow
+// command level ("error" or "warning"); GitHub only recognizes those two
+// (plus "notice", which we don't need here).
+func githubLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// githubReporter renders findings as GitHub Actions workflow commands
+// (`::error file=...,line=...::...`), which GitHub Actions surfaces as
+// inline annotations on the triggering commit/PR diff.
+type githubReporter struct{}
+
+func (githubReporter) Write(w io.Writer, toolName string, findings []Finding) error {
+	for _, f := range findings {
+		level := githubLevel(f.Severity)
+		message := escapeGitHubMessage(fmt.Sprintf("[%s] %s: %s", toolName, f.Resource, f.Message))
+
+		var params []string
+		if f.File != "" {
+			params = append(params, "file="+f.File)
+			if f.Line > 0 {
+				params = append(params, fmt.Sprintf("line=%d", f.Line))
+			}
+		}
+
+		command := "::" + level
+		if len(params) > 0 {
+			command += " " + strings.Join(params, ",")
+		}
+		command += "::" + message
+
+		if _, err := fmt.Fprintln(w, command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeGitHubMessage escapes the characters GitHub's workflow command
+// format treats specially in a command's message/value.
+func escapeGitHubMessage(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
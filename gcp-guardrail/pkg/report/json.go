@@ -0,0 +1,46 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonFinding struct {
+	RuleID      string `json:"rule_id,omitempty"`
+	Severity    string `json:"severity"`
+	Resource    string `json:"resource"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+	Fix         string `json:"fix,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+}
+
+type jsonDocument struct {
+	Tool     string        `json:"tool"`
+	Findings []jsonFinding `json:"findings"`
+}
+
+// jsonReporter renders findings as a single JSON document, the common
+// currency every CI-oriented format ultimately derives from.
+type jsonReporter struct{}
+
+func (jsonReporter) Write(w io.Writer, toolName string, findings []Finding) error {
+	doc := jsonDocument{Tool: toolName}
+	for _, f := range findings {
+		doc.Findings = append(doc.Findings, jsonFinding{
+			RuleID:      f.RuleID,
+			Severity:    f.Severity,
+			Resource:    f.Resource,
+			Message:     f.Message,
+			Remediation: f.Remediation,
+			Fix:         f.Fix,
+			File:        f.File,
+			Line:        f.Line,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitTestSuites is the JUnit XML root element. One <testcase> is
+// emitted per RuleID; a rule with findings gets one <failure> per Finding
+// so CI systems can fail the build on regressions.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name     string         `xml:"name,attr"`
+	Failures []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReporter renders findings as JUnit XML, grouping them by RuleID
+// into one <testcase> per rule.
+type junitReporter struct{}
+
+func (junitReporter) Write(w io.Writer, toolName string, findings []Finding) error {
+	byRule := make(map[string][]Finding)
+	var order []string
+	for _, f := range findings {
+		name := f.RuleID
+		if name == "" {
+			name = "unclassified"
+		}
+		if _, seen := byRule[name]; !seen {
+			order = append(order, name)
+		}
+		byRule[name] = append(byRule[name], f)
+	}
+
+	suite := junitTestSuite{Name: toolName}
+	for _, name := range order {
+		testCase := junitTestCase{Name: name}
+		for _, f := range byRule[name] {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: fmt.Sprintf("[%s] %s", f.Severity, f.Message),
+				Text:    fmt.Sprintf("Resource: %s\nRemediation: %s", f.Resource, f.Remediation),
+			})
+		}
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suite.Failures += len(testCase.Failures)
+	}
+
+	doc := junitTestSuites{
+		Name:     toolName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := w.Write([]byte("\n"))
+	return err
+}
@@ -0,0 +1,100 @@
+// Package report defines a scanner-agnostic Finding and a pluggable
+// Reporter so tf-validator, iam-analyzer, misconfig-scanner, sa-tracker,
+// and log-watcher can all emit the same CI-oriented output formats
+// (SARIF, JUnit, GitHub Actions annotations, JSON) instead of each
+// maintaining its own copy of those writers.
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// Finding is the common shape every scanner's native result type is
+// converted into before being handed to a Reporter.
+type Finding struct {
+	// RuleID identifies the policy/check that produced this finding
+	// (e.g. a Rego policy name or a Check.Name()); used as SARIF's
+	// ruleId and JUnit's testcase name.
+	RuleID string
+	// Severity is one of CRITICAL, HIGH, MEDIUM, LOW.
+	Severity string
+	// Resource is a human-readable identifier for the offending
+	// resource, e.g. "google_storage_bucket.example".
+	Resource string
+	// Message is the finding's description.
+	Message string
+	// Remediation is guidance for how to fix the finding, if any.
+	Remediation string
+	// Fix is a concrete suggested replacement value or snippet, if any;
+	// left empty when no mechanical fix is available.
+	Fix string
+	// File and Line locate the offending resource in source, if known,
+	// populated for SARIF's physicalLocation. Line is 1-indexed; File
+	// empty means no location is available.
+	File string
+	Line int
+}
+
+// Format selects which Reporter Write uses.
+type Format string
+
+const (
+	TextFormat   Format = "text"
+	JSONFormat   Format = "json"
+	SARIFFormat  Format = "sarif"
+	JUnitFormat  Format = "junit"
+	GitHubFormat Format = "github"
+)
+
+// Reporter renders a set of Findings to w.
+type Reporter interface {
+	Write(w io.Writer, toolName string, findings []Finding) error
+}
+
+// NewReporter returns the Reporter for format.
+func NewReporter(format Format) (Reporter, error) {
+	switch format {
+	case JSONFormat:
+		return jsonReporter{}, nil
+	case SARIFFormat:
+		return sarifReporter{}, nil
+	case JUnitFormat:
+		return junitReporter{}, nil
+	case GitHubFormat:
+		return githubReporter{}, nil
+	case TextFormat:
+		return textReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// Write renders findings under toolName (the SARIF driver name / JUnit
+// suite name) to w in format.
+func Write(w io.Writer, toolName string, findings []Finding, format Format) error {
+	reporter, err := NewReporter(format)
+	if err != nil {
+		return err
+	}
+	return reporter.Write(w, toolName, findings)
+}
+
+// textReporter renders findings as simple human-readable lines, for
+// callers that want a quick CLI summary without a dedicated text writer
+// of their own.
+type textReporter struct{}
+
+func (textReporter) Write(w io.Writer, toolName string, findings []Finding) error {
+	for i, f := range findings {
+		if _, err := fmt.Fprintf(w, "%d. [%s] %s: %s\n", i+1, f.Severity, f.Resource, f.Message); err != nil {
+			return err
+		}
+		if f.Remediation != "" {
+			if _, err := fmt.Fprintf(w, "   Remediation: %s\n", f.Remediation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// sarifLog is the root SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevel maps a Finding severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifReporter renders findings as SARIF 2.1.0 JSON. A Finding with File
+// set gets a physicalLocation (pointing GitHub code scanning at the exact
+// source line); every Finding also gets a logicalLocation keyed on
+// Resource so tools without source access still have something to group
+// by.
+type sarifReporter struct{}
+
+func (sarifReporter) Write(w io.Writer, toolName string, findings []Finding) error {
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, f := range findings {
+		ruleID := f.RuleID
+		if ruleID == "" {
+			ruleID = toolName + "/" + f.Severity
+		}
+		if !ruleIDs[ruleID] {
+			ruleIDs[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID, Name: ruleID})
+		}
+
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: f.Resource}},
+		}
+		if f.File != "" {
+			line := f.Line
+			if line <= 0 {
+				line = 1
+			}
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: f.File},
+				Region:           sarifRegion{StartLine: line},
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    ruleID,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  toolName,
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envBackend reads secrets from environment variables named
+// GCPGUARDRAIL_<KEY> (key uppercased, dashes replaced with underscores).
+// Set and Delete aren't supported since a process can't durably modify
+// its own parent's environment.
+type envBackend struct{}
+
+func newEnvBackend() *envBackend { return &envBackend{} }
+
+func (b *envBackend) Name() string { return "env" }
+
+func envVarName(key string) string {
+	return "GCPGUARDRAIL_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+func (b *envBackend) Get(key string) (string, error) {
+	value, ok := os.LookupEnv(envVarName(key))
+	if !ok {
+		return "", fmt.Errorf("env: %s is not set", envVarName(key))
+	}
+	return value, nil
+}
+
+func (b *envBackend) Set(key, value string) error {
+	return fmt.Errorf("env: secrets backend is read-only; set %s directly", envVarName(key))
+}
+
+func (b *envBackend) Delete(key string) error {
+	return fmt.Errorf("env: secrets backend is read-only; unset %s directly", envVarName(key))
+}
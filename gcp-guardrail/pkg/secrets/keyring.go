@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringBackend stores secrets in the OS-native credential store (macOS
+// Keychain, Windows Credential Manager, the Secret Service/libsecret on
+// Linux) via go-keyring, namespaced under service.
+type keyringBackend struct {
+	service string
+}
+
+func newKeyringBackend(cfg Config) *keyringBackend {
+	service := cfg.Service
+	if service == "" {
+		service = "gcp-guardrail"
+	}
+	return &keyringBackend{service: service}
+}
+
+func (b *keyringBackend) Name() string { return "keyring" }
+
+func (b *keyringBackend) Get(key string) (string, error) {
+	value, err := keyring.Get(b.service, key)
+	if err != nil {
+		return "", fmt.Errorf("keyring: failed to get %s: %w", key, err)
+	}
+	return value, nil
+}
+
+func (b *keyringBackend) Set(key, value string) error {
+	if err := keyring.Set(b.service, key, value); err != nil {
+		return fmt.Errorf("keyring: failed to set %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *keyringBackend) Delete(key string) error {
+	if err := keyring.Delete(b.service, key); err != nil {
+		return fmt.Errorf("keyring: failed to delete %s: %w", key, err)
+	}
+	return nil
+}
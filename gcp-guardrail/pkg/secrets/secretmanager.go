@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// secretManagerBackend stores secrets as GCP Secret Manager secrets named
+// "gcp-guardrail-<key>" within projectID, creating the secret (and its
+// first version) on first Set.
+type secretManagerBackend struct {
+	client    *secretmanager.Client
+	projectID string
+}
+
+func newSecretManagerBackend(ctx context.Context, cfg Config) (*secretManagerBackend, error) {
+	if cfg.ProjectID == "" {
+		return nil, fmt.Errorf("secretmanager: project ID is required")
+	}
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("secretmanager: failed to create client: %w", err)
+	}
+	return &secretManagerBackend{client: client, projectID: cfg.ProjectID}, nil
+}
+
+func (b *secretManagerBackend) Name() string { return "secretmanager" }
+
+func (b *secretManagerBackend) secretID(key string) string {
+	return fmt.Sprintf("gcp-guardrail-%s", key)
+}
+
+func (b *secretManagerBackend) secretName(key string) string {
+	return fmt.Sprintf("projects/%s/secrets/%s", b.projectID, b.secretID(key))
+}
+
+func (b *secretManagerBackend) Get(key string) (string, error) {
+	ctx := context.Background()
+	resp, err := b.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: b.secretName(key) + "/versions/latest",
+	})
+	if err != nil {
+		return "", fmt.Errorf("secretmanager: failed to access %s: %w", key, err)
+	}
+	return string(resp.Payload.Data), nil
+}
+
+func (b *secretManagerBackend) Set(key, value string) error {
+	ctx := context.Background()
+	name := b.secretName(key)
+
+	if _, err := b.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: name}); err != nil {
+		_, err := b.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+			Parent:   fmt.Sprintf("projects/%s", b.projectID),
+			SecretId: b.secretID(key),
+			Secret: &secretmanagerpb.Secret{
+				Replication: &secretmanagerpb.Replication{
+					Replication: &secretmanagerpb.Replication_Automatic_{
+						Automatic: &secretmanagerpb.Replication_Automatic{},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("secretmanager: failed to create secret %s: %w", key, err)
+		}
+	}
+
+	if _, err := b.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  name,
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(value)},
+	}); err != nil {
+		return fmt.Errorf("secretmanager: failed to add version for %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *secretManagerBackend) Delete(key string) error {
+	ctx := context.Background()
+	if err := b.client.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{Name: b.secretName(key)}); err != nil {
+		return fmt.Errorf("secretmanager: failed to delete %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package secrets abstracts where gcp-guardrail reads and writes
+// credentials for third-party integrations (currently just Wiz's
+// client-credentials), so they never need to be passed as plaintext
+// command-line flags, where they'd leak into shell history and ps output.
+package secrets
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backend reads, writes, and deletes a single named secret.
+type Backend interface {
+	// Name identifies the backend, for log messages and error context.
+	Name() string
+	// Get returns the secret stored under key, or an error if unset.
+	Get(key string) (string, error)
+	// Set stores value under key, creating or overwriting it.
+	Set(key, value string) error
+	// Delete removes the secret stored under key, if any.
+	Delete(key string) error
+}
+
+// BackendType selects which Backend New constructs.
+type BackendType string
+
+const (
+	KeyringBackend       BackendType = "keyring"
+	SecretManagerBackend BackendType = "secretmanager"
+	EnvBackend           BackendType = "env"
+)
+
+// Config holds settings needed to construct any backend; fields a
+// particular backend doesn't need are ignored.
+type Config struct {
+	// Service namespaces keyring entries. Defaults to "gcp-guardrail".
+	Service string
+	// ProjectID is the GCP project secretmanager secrets are stored in.
+	ProjectID string
+}
+
+// New constructs the Backend selected by backendType. An empty
+// backendType defaults to KeyringBackend.
+func New(ctx context.Context, backendType BackendType, cfg Config) (Backend, error) {
+	switch backendType {
+	case KeyringBackend, "":
+		return newKeyringBackend(cfg), nil
+	case SecretManagerBackend:
+		return newSecretManagerBackend(ctx, cfg)
+	case EnvBackend:
+		return newEnvBackend(), nil
+	default:
+		return nil, fmt.Errorf("unsupported secret backend: %s", backendType)
+	}
+}
@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hieptle/gcp-guardrail/pkg/rego"
+)
+
+// AccessDecision is the result of evaluating an access policy against the
+// result of the chain's "plan" stage.
+type AccessDecision struct {
+	Allowed    bool             `json:"allowed"`
+	Violations []rego.Violation `json:"violations"`
+	RiskScore  int              `json:"risk_score"`
+}
+
+// AccessEvaluator wraps the RBAC-like access-control Rego policies loaded
+// from --access-policy-dir, kept as a separate Evaluator/package
+// namespace from the per-resource-type terraform.gcp.* compliance
+// policies so configuration compliance and actor/environment access
+// control stay independently pluggable.
+type AccessEvaluator struct {
+	evaluator *rego.Evaluator
+}
+
+// NewAccessEvaluator loads every .rego policy under policyDir, expected
+// under the "plan" and "access" packages.
+func NewAccessEvaluator(ctx context.Context, policyDir string) (*AccessEvaluator, error) {
+	evaluator, err := rego.NewEvaluator(ctx, []string{policyDir})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load access policies: %w", err)
+	}
+	return &AccessEvaluator{evaluator: evaluator}, nil
+}
+
+// Evaluate runs the Digger-style two-stage plan->access chain. The "plan"
+// package is evaluated with report's violations and their summed risk
+// score as input; its own EvaluationResult (the violations and warnings
+// of its deny/warn rules, with their counts) is exposed to the "access"
+// package as input.plan_policy, next to input.actor and
+// input.environment, and the access package decides whether actor may
+// apply this change in environment. The risk score is not part of
+// input.plan_policy; it is returned as AccessDecision.RiskScore.
+func (ae *AccessEvaluator) Evaluate(report *Report, actor, environment string) (*AccessDecision, error) {
+	riskScore := 0
+	for _, v := range report.Violations {
+		riskScore += severityScore(v.Severity)
+	}
+
+	violations, err := toJSONValue(report.Violations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode violations for access evaluation: %w", err)
+	}
+
+	results, err := ae.evaluator.EvaluateChain([]rego.Stage{
+		{
+			PackagePath: "plan",
+			Input: map[string]interface{}{
+				"violations": violations,
+				"risk_score": riskScore,
+			},
+		},
+		{
+			PackagePath: "access",
+			Input: map[string]interface{}{
+				"actor":       actor,
+				"environment": environment,
+			},
+			FromStage: map[string]int{"plan_policy": 0},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessResult := results[1]
+	return &AccessDecision{
+		Allowed:    len(accessResult.Violations) == 0,
+		Violations: accessResult.Violations,
+		RiskScore:  riskScore,
+	}, nil
+}
+
+// severityScore weights a violation's severity for RiskScore. It's a sum
+// across every violation rather than severityRank's single-value max, so
+// it's kept local to this file instead of reusing severityRank.
+func severityScore(severity string) int {
+	switch severity {
+	case "CRITICAL":
+		return 10
+	case "HIGH":
+		return 5
+	case "MEDIUM":
+		return 2
+	case "LOW":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toJSONValue round-trips v through encoding/json so it can be used as
+// Rego input even when it's a typed Go struct/slice rather than a plain
+// map[string]interface{}.
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hieptle/gcp-guardrail/pkg/report"
+)
+
+// ToFindings converts a Report's Violations into the shared report.Finding
+// shape, so tf-validator can hand its results to pkg/report's SARIF/JUnit/
+// GitHub reporters alongside its own writeSARIFReport/writeJSONReport.
+func ToFindings(r *Report) []report.Finding {
+	findings := make([]report.Finding, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		findings = append(findings, report.Finding{
+			RuleID:   v.Policy,
+			Severity: v.Severity,
+			Resource: fmt.Sprintf("%s.%s", v.ResourceType, v.ResourceName),
+			Message:  v.Message,
+			File:     v.File,
+			Line:     v.Line,
+		})
+	}
+	return findings
+}
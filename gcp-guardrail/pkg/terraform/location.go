@@ -0,0 +1,71 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ResourceLocation is the file and line a resource block was declared at,
+// resolved by LocateResources. A Terraform plan's JSON has no source
+// location information of its own (it only carries the evaluated
+// attributes), so physicalLocation data for SARIF output has to come
+// from parsing the original .tf source alongside the plan.
+type ResourceLocation struct {
+	File string
+	Line int
+}
+
+// LocateResources parses every .tf file directly under dir and returns
+// each resource block's location keyed by "<type>.<name>", matching how
+// Violation.ResourceType/ResourceName identify a resource. Modules nested
+// in subdirectories aren't descended into, mirroring how `terraform show
+// -json`'s root_module resources are addressed relative to the root.
+func LocateResources(dir string) (map[string]ResourceLocation, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terraform source directory %s: %w", dir, err)
+	}
+
+	locations := make(map[string]ResourceLocation)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tf") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		file, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, diags)
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+
+			key := block.Labels[0] + "." + block.Labels[1]
+			locations[key] = ResourceLocation{
+				File: path,
+				Line: block.DefRange().Start.Line,
+			}
+		}
+	}
+
+	return locations, nil
+}
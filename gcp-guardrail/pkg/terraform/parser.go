@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 // Resource represents a Terraform resource
@@ -0,0 +1,181 @@
+package terraform
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ReportFormat defines the format of the terraform validation report
+type ReportFormat string
+
+const (
+	// TextFormat outputs the report in a human-readable text format
+	TextFormat ReportFormat = "text"
+	// JSONFormat outputs the report in JSON format
+	JSONFormat ReportFormat = "json"
+	// CSVFormat outputs the report in CSV format
+	CSVFormat ReportFormat = "csv"
+	// SARIFFormat outputs the report as SARIF 2.1.0 for GitHub Code Scanning and similar tools
+	SARIFFormat ReportFormat = "sarif"
+)
+
+// Violation is a single Rego policy violation found against a plan's
+// resource.
+type Violation struct {
+	Severity     string `json:"severity"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	Message      string `json:"message"`
+	Policy       string `json:"policy"`
+	// Scope is the policy's declared Gatekeeper-style enforcement scope
+	// ("plan" or "apply"), empty if the policy declared none.
+	Scope string `json:"scope,omitempty"`
+	// Deny reports whether the policy's enforcement rule declared "deny"
+	// among its actions (or declared no enforcement rule at all, the
+	// backward-compatible default). tf-validator's --fail-on only gates
+	// on violations where this is true; "warn"/"dryrun"-only violations
+	// are always just reported.
+	Deny bool `json:"deny"`
+	// File and Line locate the resource block in the .tf source that
+	// produced this resource change, resolved via LocateResources when
+	// --tf-dir is set. Empty/zero when no source directory was given or
+	// the resource block couldn't be found in it.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Report represents a Terraform plan validation report
+type Report struct {
+	PlanFile    string      `json:"plan_file"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Violations  []Violation `json:"violations"`
+	Stats       struct {
+		TotalViolations int `json:"total_violations"`
+		CriticalCount   int `json:"critical_count"`
+		HighCount       int `json:"high_count"`
+		MediumCount     int `json:"medium_count"`
+		LowCount        int `json:"low_count"`
+	} `json:"stats"`
+}
+
+// NewReport creates a new terraform validation report from a Validator's
+// aggregated violations.
+func NewReport(planFile string, violations []Violation) *Report {
+	report := &Report{
+		PlanFile:    planFile,
+		GeneratedAt: time.Now(),
+		Violations:  violations,
+	}
+
+	report.Stats.TotalViolations = len(violations)
+	for _, v := range violations {
+		switch strings.ToUpper(v.Severity) {
+		case "CRITICAL":
+			report.Stats.CriticalCount++
+		case "HIGH":
+			report.Stats.HighCount++
+		case "MEDIUM":
+			report.Stats.MediumCount++
+		case "LOW":
+			report.Stats.LowCount++
+		}
+	}
+
+	return report
+}
+
+// WriteReport writes the report to the specified writer in the specified format
+func WriteReport(w io.Writer, report *Report, format ReportFormat) error {
+	switch format {
+	case TextFormat:
+		return writeTextReport(w, report)
+	case JSONFormat:
+		return writeJSONReport(w, report)
+	case CSVFormat:
+		return writeCSVReport(w, report)
+	case SARIFFormat:
+		return writeSARIFReport(w, report)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// writeTextReport writes the report in a human-readable text format
+func writeTextReport(w io.Writer, report *Report) error {
+	fmt.Fprintf(w, "# TERRAFORM PLAN VALIDATION REPORT\n")
+	fmt.Fprintf(w, "Plan: %s\n", report.PlanFile)
+	fmt.Fprintf(w, "Generated: %s\n\n", report.GeneratedAt.Format(time.RFC1123))
+
+	fmt.Fprintf(w, "## SUMMARY\n")
+	fmt.Fprintf(w, "Total violations: %d\n", report.Stats.TotalViolations)
+	fmt.Fprintf(w, "  Critical: %d\n", report.Stats.CriticalCount)
+	fmt.Fprintf(w, "  High: %d\n", report.Stats.HighCount)
+	fmt.Fprintf(w, "  Medium: %d\n", report.Stats.MediumCount)
+	fmt.Fprintf(w, "  Low: %d\n\n", report.Stats.LowCount)
+
+	fmt.Fprintf(w, "## VIOLATIONS\n")
+	for i, v := range report.Violations {
+		fmt.Fprintf(w, "%d. [%s] %s\n", i+1, v.Severity, v.Message)
+		fmt.Fprintf(w, "   Resource: %s.%s\n", v.ResourceType, v.ResourceName)
+		fmt.Fprintf(w, "   Policy: %s\n", v.Policy)
+		if v.Scope != "" {
+			fmt.Fprintf(w, "   Scope: %s\n", v.Scope)
+		}
+		if !v.Deny {
+			fmt.Fprintf(w, "   Enforcement: non-blocking (warn/dryrun)\n")
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	return nil
+}
+
+// writeJSONReport writes the report in JSON format
+func writeJSONReport(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// writeCSVReport writes the report in CSV format
+func writeCSVReport(w io.Writer, report *Report) error {
+	csvWriter := csv.NewWriter(w)
+
+	headers := []string{"Severity", "ResourceType", "ResourceName", "Message", "Policy"}
+	if err := csvWriter.Write(headers); err != nil {
+		return err
+	}
+
+	for _, v := range report.Violations {
+		row := []string{v.Severity, v.ResourceType, v.ResourceName, v.Message, v.Policy}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WriteReportToFile writes the report to a file
+func WriteReportToFile(filename string, report *Report, format ReportFormat) error {
+	var file *os.File
+	var err error
+
+	if filename == "" {
+		file = os.Stdout
+	} else {
+		file, err = os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		defer file.Close()
+	}
+
+	return WriteReport(file, report, format)
+}
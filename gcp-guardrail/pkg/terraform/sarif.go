@@ -0,0 +1,116 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifLog is the root SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevel maps a Violation severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// writeSARIFReport writes the report as SARIF 2.1.0 JSON. Each Violation
+// becomes one result, with the rule id derived from its originating
+// policy and the location synthesized from its resource type/name.
+func writeSARIFReport(w io.Writer, report *Report) error {
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, v := range report.Violations {
+		ruleID := v.Policy
+		if ruleID == "" {
+			ruleID = "terraform/" + v.Severity
+		}
+		if !ruleIDs[ruleID] {
+			ruleIDs[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID, Name: ruleID})
+		}
+
+		location := fmt.Sprintf("%s.%s", v.ResourceType, v.ResourceName)
+		results = append(results, sarifResult{
+			RuleID: ruleID,
+			Level:  sarifLevel(v.Severity),
+			Message: sarifMessage{
+				Text: v.Message,
+			},
+			Locations: []sarifLocation{
+				{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: location}}},
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  "gcpgolang-tf-validator",
+						Rules: rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -0,0 +1,123 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hieptle/gcp-guardrail/pkg/rego"
+)
+
+// Validator evaluates a parsed Terraform Plan's GCP resources against
+// Rego policies, closing the gap between the plan parser and the
+// admission-controller-style rego.Evaluator already used elsewhere in
+// this repo.
+type Validator struct {
+	evaluator *rego.Evaluator
+
+	// enforcement caches each resource type's package's EvaluateEnforcement
+	// result, since the same package is evaluated once per matching
+	// resource and its enforcement rule never changes between calls.
+	enforcement map[string]*rego.Enforcement
+
+	// locations is populated by SetSourceDir and used to attach a
+	// File/Line to each Violation for SARIF's physicalLocation. Nil
+	// means no source directory was given, so Violations carry no
+	// location.
+	locations map[string]ResourceLocation
+}
+
+// SetSourceDir points the Validator at the directory containing the
+// plan's .tf source, so Validate can attach a File/Line to each
+// Violation via LocateResources. Not calling this leaves Violations
+// without a location.
+func (v *Validator) SetSourceDir(dir string) error {
+	locations, err := LocateResources(dir)
+	if err != nil {
+		return err
+	}
+	v.locations = locations
+	return nil
+}
+
+// NewValidator creates a Validator that loads every .rego policy under
+// policyDir. Policies are expected to live under the terraform.gcp.<type>
+// package for each Terraform resource type they cover, e.g.
+// terraform.gcp.google_storage_bucket.
+func NewValidator(ctx context.Context, policyDir string) (*Validator, error) {
+	evaluator, err := rego.NewEvaluator(ctx, []string{policyDir})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load terraform policies: %w", err)
+	}
+
+	return &Validator{evaluator: evaluator, enforcement: make(map[string]*rego.Enforcement)}, nil
+}
+
+// enforcementFor returns the cached Enforcement for packagePath, querying
+// the evaluator on first use.
+func (v *Validator) enforcementFor(packagePath string) (*rego.Enforcement, error) {
+	if e, ok := v.enforcement[packagePath]; ok {
+		return e, nil
+	}
+
+	e, err := v.evaluator.EvaluateEnforcement(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	v.enforcement[packagePath] = e
+	return e, nil
+}
+
+// Validate evaluates every GCP resource being created or updated by plan
+// against its resource type's policy package and returns the aggregated
+// violations as a Report. planFile is recorded on the report for display;
+// it isn't otherwise used. enforcementScope selects which of a policy's
+// declared enforcement scopes ("plan" or "apply") applies to this run; a
+// policy whose own scope doesn't match is skipped entirely. Policies with
+// no enforcement rule apply to every scope and default to "deny".
+func (v *Validator) Validate(planFile string, plan *Plan, enforcementScope string) (*Report, error) {
+	var violations []Violation
+
+	for _, change := range plan.ResourceChanges {
+		if !isGCPResource(change.Resource.Type) || change.Action == "delete" {
+			continue
+		}
+
+		packagePath := "terraform.gcp." + change.Resource.Type
+
+		enforcement, err := v.enforcementFor(packagePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate enforcement metadata for %s: %w", packagePath, err)
+		}
+		if !enforcement.AppliesToScope(enforcementScope) {
+			continue
+		}
+
+		input := map[string]interface{}{
+			"name":       change.Resource.Name,
+			"attributes": change.After,
+		}
+
+		result, err := v.evaluator.Evaluate(packagePath, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate policies for %s.%s: %w", change.Resource.Type, change.Resource.Name, err)
+		}
+
+		location := v.locations[change.Resource.Type+"."+change.Resource.Name]
+
+		for _, found := range append(result.Violations, result.Warnings...) {
+			violations = append(violations, Violation{
+				Severity:     found.Severity,
+				ResourceType: change.Resource.Type,
+				ResourceName: change.Resource.Name,
+				Message:      found.Message,
+				Policy:       found.Policy,
+				Scope:        enforcement.ScopeOrDefault(),
+				Deny:         enforcement.HasAction("deny"),
+				File:         location.File,
+				Line:         location.Line,
+			})
+		}
+	}
+
+	return NewReport(planFile, violations), nil
+}
@@ -0,0 +1,175 @@
+// Package wiz is a minimal client for the Wiz Security Graph API:
+// OAuth2 client-credentials authentication and GraphQL queries with
+// cursor-based pagination and retry-with-backoff, used by
+// misconfig-scanner's Wiz integration.
+package wiz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// defaultAuthURL is Wiz's tenant-agnostic OAuth2 token endpoint.
+const defaultAuthURL = "https://auth.app.wiz.io/oauth/token"
+
+// maxRetries bounds how many attempts, in all, query makes at a request
+// that keeps failing with a 429, a 5xx or a transport error.
+const maxRetries = 5
+
+// Config holds the settings needed to construct a Client.
+type Config struct {
+	// ClientID and ClientSecret are the Wiz service account's
+	// client-credentials, typically sourced from --wiz-client-id and
+	// --wiz-client-secret.
+	ClientID     string
+	ClientSecret string
+	// AuthURL overrides the OAuth2 token endpoint. Defaults to
+	// defaultAuthURL.
+	AuthURL string
+	// Audience is the OAuth2 audience parameter Wiz requires to scope the
+	// issued token to its GraphQL API, sourced from --wiz-audience.
+	Audience string
+	// Endpoint is the tenant's GraphQL API URL, sourced from
+	// --wiz-endpoint (e.g. https://api.<tenant>.app.wiz.io/graphql).
+	Endpoint string
+}
+
+// Client is a Wiz API client authenticated via OAuth2 client-credentials.
+// Its underlying http.Client transparently caches the access token and
+// refreshes it once it expires, so callers never have to think about
+// token lifetime themselves.
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewClient builds a Client for cfg. The client-credentials token isn't
+// fetched until the first request; a bad ClientID/ClientSecret surfaces as
+// an error from the first call to ListVulnerabilities, not from NewClient.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("wiz: client ID and client secret are required")
+	}
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("wiz: endpoint is required (set --wiz-endpoint)")
+	}
+
+	authURL := cfg.AuthURL
+	if authURL == "" {
+		authURL = defaultAuthURL
+	}
+
+	ccConfig := clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     authURL,
+	}
+	if cfg.Audience != "" {
+		ccConfig.EndpointParams = url.Values{"audience": {cfg.Audience}}
+	}
+
+	return &Client{
+		httpClient: ccConfig.Client(ctx),
+		endpoint:   cfg.Endpoint,
+	}, nil
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+// Authenticate performs the OAuth2 client-credentials exchange and a
+// minimal GraphQL request, surfacing any credential failure immediately
+// rather than on the first real query. It's used by `login wiz` to
+// validate credentials before storing them.
+func (c *Client) Authenticate(ctx context.Context) error {
+	return c.query(ctx, `query { __typename }`, nil, nil)
+}
+
+// query executes a single GraphQL request against the Wiz API, decoding
+// its "data" field into out. A request that fails with a 429, a 5xx or a
+// transport error is retried here with exponential backoff (capped at
+// 30s), for at most maxRetries attempts in all. Any other non-200 status,
+// 401 included, is returned as an error without a retry; the oauth2
+// client replaces its cached token only once that token expires.
+func (c *Client) query(ctx context.Context, gqlQuery string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: gqlQuery, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("wiz: failed to marshal GraphQL request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+			if backoff > 30*time.Second {
+				backoff = 30 * time.Second
+			}
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("wiz: failed to build GraphQL request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("wiz: GraphQL request failed: %w", err)
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("wiz: failed to read GraphQL response: %w", err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("wiz: GraphQL request returned %d: %s", resp.StatusCode, string(respBody))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("wiz: GraphQL request returned %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		var gqlResp graphQLResponse
+		if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+			return fmt.Errorf("wiz: failed to decode GraphQL response: %w", err)
+		}
+		if len(gqlResp.Errors) > 0 {
+			return fmt.Errorf("wiz: GraphQL errors: %s", gqlResp.Errors[0].Message)
+		}
+		if out != nil {
+			if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+				return fmt.Errorf("wiz: failed to decode GraphQL data: %w", err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("wiz: GraphQL request failed after %d attempts: %w", maxRetries, lastErr)
+}
@@ -0,0 +1,138 @@
+package wiz
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pageSize is how many vulnerabilityFindings nodes ListVulnerabilities
+// requests per page.
+const pageSize = 100
+
+// VulnerabilityFilter narrows ListVulnerabilities to a single Wiz project
+// (tenant sub-scope), sourced from --wiz-project-filter. An empty
+// ProjectID fetches findings across every project the token can see.
+type VulnerabilityFilter struct {
+	ProjectID string
+}
+
+// Vulnerability is gcp-guardrail's normalized view of a Wiz
+// vulnerabilityFindings node.
+type Vulnerability struct {
+	ID           string
+	Name         string
+	Description  string
+	Severity     string
+	CVE          string
+	CVSSVector   string
+	ResourceName string
+	ResourceType string
+	FirstSeen    time.Time
+	Status       string
+	Remediation  string
+}
+
+const vulnerabilityFindingsQuery = `
+query VulnerabilityFindings($first: Int!, $after: String, $filterBy: VulnerabilityFindingFilters) {
+  vulnerabilityFindings(first: $first, after: $after, filterBy: $filterBy) {
+    pageInfo {
+      endCursor
+      hasNextPage
+    }
+    nodes {
+      id
+      name
+      description
+      severity
+      status
+      firstDetectedAt
+      remediation
+      vulnerableAsset {
+        name
+        type
+      }
+      vulnerableInfo {
+        cveId
+        cvssVector: cvssV3Vector
+      }
+    }
+  }
+}
+`
+
+type vulnerabilityFindingsResponse struct {
+	VulnerabilityFindings struct {
+		PageInfo struct {
+			EndCursor   string `json:"endCursor"`
+			HasNextPage bool   `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Nodes []struct {
+			ID              string    `json:"id"`
+			Name            string    `json:"name"`
+			Description     string    `json:"description"`
+			Severity        string    `json:"severity"`
+			Status          string    `json:"status"`
+			FirstDetectedAt time.Time `json:"firstDetectedAt"`
+			Remediation     string    `json:"remediation"`
+			VulnerableAsset struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"vulnerableAsset"`
+			VulnerableInfo struct {
+				CVEID      string `json:"cveId"`
+				CVSSVector string `json:"cvssVector"`
+			} `json:"vulnerableInfo"`
+		} `json:"nodes"`
+	} `json:"vulnerabilityFindings"`
+}
+
+// ListVulnerabilities fetches every vulnerabilityFindings node matching
+// filter, following pageInfo.endCursor/hasNextPage until the API reports
+// no more pages.
+func (c *Client) ListVulnerabilities(ctx context.Context, filter VulnerabilityFilter) ([]Vulnerability, error) {
+	var out []Vulnerability
+	after := ""
+
+	for {
+		variables := map[string]interface{}{
+			"first": pageSize,
+		}
+		if after != "" {
+			variables["after"] = after
+		}
+		if filter.ProjectID != "" {
+			variables["filterBy"] = map[string]interface{}{
+				"projectId": []string{filter.ProjectID},
+			}
+		}
+
+		var resp vulnerabilityFindingsResponse
+		if err := c.query(ctx, vulnerabilityFindingsQuery, variables, &resp); err != nil {
+			return nil, fmt.Errorf("wiz: failed to list vulnerability findings: %w", err)
+		}
+
+		for _, node := range resp.VulnerabilityFindings.Nodes {
+			out = append(out, Vulnerability{
+				ID:           node.ID,
+				Name:         node.Name,
+				Description:  node.Description,
+				Severity:     node.Severity,
+				CVE:          node.VulnerableInfo.CVEID,
+				CVSSVector:   node.VulnerableInfo.CVSSVector,
+				ResourceName: node.VulnerableAsset.Name,
+				ResourceType: node.VulnerableAsset.Type,
+				FirstSeen:    node.FirstDetectedAt,
+				Status:       node.Status,
+				Remediation:  node.Remediation,
+			})
+		}
+
+		if !resp.VulnerabilityFindings.PageInfo.HasNextPage {
+			break
+		}
+		after = resp.VulnerabilityFindings.PageInfo.EndCursor
+	}
+
+	return out, nil
+}
@@ -20,6 +20,7 @@ var (
 	port        = flag.String("port", "8080", "HTTP server port")
 	policiesDir = flag.String("policies", "../policies", "Path to Rego policies directory")
 	templatesDir = flag.String("templates", "templates", "Path to HTML templates directory")
+	constraintsDir = flag.String("constraints", "", "Path to a directory of *.template.yaml ConstraintTemplates and *.constraint.yaml Constraints to preload for /admissionreview (default: none loaded)")
 )
 
 type PlaygroundServer struct {
@@ -34,6 +35,17 @@ type EvaluationRequest struct {
 	PolicyText  string `json:"policy_text,omitempty"`
 }
 
+// ConstraintRequest is the body for /evaluate-constraint: a
+// ConstraintTemplate YAML document, the parameters to instantiate it
+// with, and an admission-review-shaped "review" input (the same shape
+// AdmissionRequest.Object/Namespace/... get assembled into by
+// EvaluateAdmission) to evaluate it against.
+type ConstraintRequest struct {
+	TemplateYAML string                 `json:"template_yaml"`
+	Parameters   map[string]interface{} `json:"parameters"`
+	Review       map[string]interface{} `json:"review"`
+}
+
 type TemplateData struct {
 	PolicyDirs  []string
 	ExampleJSON string
@@ -51,6 +63,8 @@ func main() {
 	// Set up HTTP handlers
 	http.HandleFunc("/", server.handleIndex)
 	http.HandleFunc("/evaluate", server.handleEvaluate)
+	http.HandleFunc("/evaluate-constraint", server.handleEvaluateConstraint)
+	http.HandleFunc("/admissionreview", server.handleAdmissionReview)
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 
 	// Start the HTTP server
@@ -73,6 +87,12 @@ func NewPlaygroundServer(policiesDir, templatesDir string) (*PlaygroundServer, e
 		return nil, fmt.Errorf("failed to create policy evaluator: %w", err)
 	}
 
+	if *constraintsDir != "" {
+		if err := loadConstraints(evaluator, *constraintsDir); err != nil {
+			return nil, fmt.Errorf("failed to load constraints: %w", err)
+		}
+	}
+
 	return &PlaygroundServer{
 		evaluator:    evaluator,
 		templates:    templates,
@@ -151,6 +171,130 @@ func (s *PlaygroundServer) handleEvaluate(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(result)
 }
 
+// handleEvaluateConstraint parses a ConstraintTemplate YAML document,
+// instantiates it with the request's parameters, and evaluates it
+// against the request's review input. Each call gets its own short-lived
+// Evaluator (rather than registering onto the shared s.evaluator) so
+// concurrent playground users trying different templates never
+// interfere with each other or with /admissionreview's preloaded
+// constraints.
+func (s *PlaygroundServer) handleEvaluateConstraint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ConstraintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := rego.ParseConstraintTemplate([]byte(req.TemplateYAML))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid ConstraintTemplate: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	evaluator, err := rego.NewEvaluator(r.Context(), nil)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create evaluator: %v", err), http.StatusInternalServerError)
+		return
+	}
+	evaluator.AddConstraintTemplate(tmpl)
+
+	constraint := &rego.Constraint{Kind: tmpl.Kind, Parameters: req.Parameters}
+	if err := evaluator.AddConstraint(constraint); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := evaluator.EvaluateConstraint(constraint, req.Review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Constraint evaluation failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleAdmissionReview accepts a Kubernetes AdmissionReview object,
+// evaluates every Constraint preloaded from --constraints (see
+// loadConstraints) against it, and returns the same AdmissionReview with
+// its Response populated, so the playground can double as a validating-
+// webhook harness for policies being tested before a real cluster
+// deployment.
+func (s *PlaygroundServer) handleAdmissionReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review rego.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	response, err := s.evaluator.EvaluateAdmission(&review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Admission evaluation failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
+
+// loadConstraints preloads every *.template.yaml ConstraintTemplate and
+// *.constraint.yaml Constraint in dir into evaluator, so /admissionreview
+// has something to evaluate without handleEvaluateConstraint's one-shot
+// "try a single template" mode needing to run first. Templates are
+// loaded before constraints since a Constraint can only be registered
+// against an already-loaded template's Kind.
+func loadConstraints(evaluator *rego.Evaluator, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read constraints directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".template.yaml") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		tmpl, err := rego.ParseConstraintTemplate(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse ConstraintTemplate %s: %w", entry.Name(), err)
+		}
+		evaluator.AddConstraintTemplate(tmpl)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".constraint.yaml") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		constraint, err := rego.ParseConstraint(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse Constraint %s: %w", entry.Name(), err)
+		}
+		if err := evaluator.AddConstraint(constraint); err != nil {
+			return fmt.Errorf("failed to register Constraint %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
 // listDirectories returns a list of subdirectories in the specified directory
 func listDirectories(dir string) ([]string, error) {
 	entries, err := os.ReadDir(dir)